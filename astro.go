@@ -0,0 +1,128 @@
+package tai64n
+
+import (
+	"math"
+	"time"
+)
+
+// A timescale a moment can be expressed in as a Julian date.
+type Timescale int
+
+const (
+	// International Atomic Time, the scale TAI64N counts in.
+	ScaleTAI Timescale = iota
+
+	// Terrestrial Time, a fixed offset from TAI.
+	ScaleTT
+
+	// Barycentric Dynamical Time, which differs from TT periodically by
+	// up to about 1.7ms.
+	ScaleTDB
+)
+
+// TT runs a fixed 32.184s ahead of TAI.
+const TTMinusTAI = 32184 * time.Millisecond
+
+const (
+	secondsPerDay = 86400
+
+	// The TAI64 epoch, 1970-01-01 00:00:00, counted in seconds from the
+	// start of Julian day 0 and of Modified Julian day 0.
+	jdEpochSeconds  = 210866760000
+	mjdEpochSeconds = 3506716800
+
+	// J2000.0 as a Julian date.
+	jdJ2000 = 2451545.0
+)
+
+// A Julian date split into the whole day number and the time elapsed in
+// that day. A float64 Julian date only resolves tens of microseconds, so
+// the split form is used to keep nanoseconds.
+//
+// Julian days start at noon. The same type is used for Modified Julian
+// dates, which start at midnight.
+type JulianDate struct {
+	Day  int64
+	Time time.Duration
+}
+
+// Return the date as a fractional day count, losing precision.
+func (jd JulianDate) Float64() float64 {
+	return float64(jd.Day) + float64(jd.Time)/float64(secondsPerDay*time.Second)
+}
+
+// Return the difference TDB-TT at this moment. It is calculated with the
+// two term series given by the USNO, which is accurate to about 30µs.
+func (tai *TAI64N) TDBMinusTT() time.Duration {
+	secs := float64(int64(tai.Seconds-TAI64OriginalBase)) + TTMinusTAI.Seconds()
+	jdTT := float64(jdEpochSeconds)/secondsPerDay + secs/secondsPerDay
+
+	g := (357.53 + 0.98560028*(jdTT-jdJ2000)) * math.Pi / 180
+
+	corr := 0.001657*math.Sin(g) + 0.000014*math.Sin(2*g)
+
+	return time.Duration(corr * float64(time.Second))
+}
+
+// Return the Julian date of this moment in the given timescale
+func (tai *TAI64N) JulianDate(scale Timescale) JulianDate {
+	return tai.dayCount(scale, jdEpochSeconds)
+}
+
+// Return the Modified Julian date of this moment in the given timescale
+func (tai *TAI64N) ModifiedJulianDate(scale Timescale) JulianDate {
+	return tai.dayCount(scale, mjdEpochSeconds)
+}
+
+// Convert from a Julian date in the given timescale
+func FromJulianDate(jd JulianDate, scale Timescale) *TAI64N {
+	return fromDayCount(jd, scale, jdEpochSeconds)
+}
+
+// Convert from a Modified Julian date in the given timescale
+func FromModifiedJulianDate(mjd JulianDate, scale Timescale) *TAI64N {
+	return fromDayCount(mjd, scale, mjdEpochSeconds)
+}
+
+func (tai *TAI64N) dayCount(scale Timescale, epoch int64) JulianDate {
+	t := tai.Add(tai.scaleOffset(scale))
+
+	secs := int64(t.Seconds-TAI64OriginalBase) + epoch
+
+	day := secs / secondsPerDay
+	rem := secs % secondsPerDay
+
+	if rem < 0 {
+		day--
+		rem += secondsPerDay
+	}
+
+	return JulianDate{
+		Day:  day,
+		Time: time.Duration(rem)*time.Second + time.Duration(t.Nanoseconds),
+	}
+}
+
+func fromDayCount(jd JulianDate, scale Timescale, epoch int64) *TAI64N {
+	t := &TAI64N{
+		Seconds: uint64(jd.Day*secondsPerDay-epoch) + TAI64OriginalBase,
+	}
+
+	t = t.Add(jd.Time)
+
+	// The TDB correction changes slowly enough that evaluating it at
+	// the TDB moment rather than the TAI one makes no difference.
+	return t.Add(-t.scaleOffset(scale))
+}
+
+// Return how far the given timescale is ahead of TAI at this moment
+func (tai *TAI64N) scaleOffset(scale Timescale) time.Duration {
+	switch scale {
+	case ScaleTT:
+		return TTMinusTAI
+	case ScaleTDB:
+		return TTMinusTAI + tai.TDBMinusTT()
+	default:
+		return 0
+	}
+}
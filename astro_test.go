@@ -0,0 +1,61 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// J2000.0 is 2000-01-01 12:00:00 TT, which is 11:58:55.816 UTC.
+var j2000 = FromTime(time.Date(2000, time.January, 1, 11, 58, 55, 816000000, time.UTC))
+
+func TestJulianDateTT(t *testing.T) {
+	jd := j2000.JulianDate(ScaleTT)
+
+	assert.Equal(t, JulianDate{Day: 2451545}, jd)
+	assert.Equal(t, 2451545.0, jd.Float64())
+
+	mjd := j2000.ModifiedJulianDate(ScaleTT)
+
+	assert.Equal(t, JulianDate{Day: 51544, Time: 12 * time.Hour}, mjd)
+}
+
+func TestJulianDateTAI(t *testing.T) {
+	jd := j2000.JulianDate(ScaleTAI)
+
+	assert.Equal(t, int64(2451544), jd.Day)
+	assert.Equal(t, 24*time.Hour-TTMinusTAI, jd.Time)
+
+	epoch := &TAI64N{Seconds: TAI64OriginalBase}
+
+	assert.Equal(t, JulianDate{Day: 40587}, epoch.ModifiedJulianDate(ScaleTAI))
+}
+
+func TestJulianDateKeepsNanoseconds(t *testing.T) {
+	m := j2000.Add(123456789)
+
+	jd := m.JulianDate(ScaleTT)
+
+	assert.Equal(t, time.Duration(123456789), jd.Time)
+	assert.True(t, FromJulianDate(jd, ScaleTT).Equal(m))
+	assert.True(t, FromModifiedJulianDate(m.ModifiedJulianDate(ScaleTT), ScaleTT).Equal(m))
+}
+
+func TestTDB(t *testing.T) {
+	corr := j2000.TDBMinusTT()
+
+	// At J2000 the mean anomaly is 357.53 degrees, so TDB-TT is small
+	// and negative.
+	assert.True(t, corr < 0 && corr > -100*time.Microsecond, "%s", corr)
+
+	// The correction never exceeds about 1.7ms.
+	for d := 0; d < 366; d += 5 {
+		c := j2000.Add(time.Duration(d) * 24 * time.Hour).TDBMinusTT()
+		assert.True(t, c < 1700*time.Microsecond && c > -1700*time.Microsecond)
+	}
+
+	m := j2000.Add(90 * 24 * time.Hour)
+
+	assert.True(t, FromJulianDate(m.JulianDate(ScaleTDB), ScaleTDB).Equal(m))
+}
@@ -0,0 +1,142 @@
+package tai64n
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+	"time"
+)
+
+// Convert each of src into dst, which must be at least as long. The leap
+// second table is only searched again when a time falls outside the
+// span between leap seconds of the one before, so sorted input converts
+// fastest, though any order converts the same as FromTime.
+func FromTimes(dst []TAI64N, src []time.Time) {
+	dst = dst[:len(src)]
+
+	var (
+		lt     = currentLeaps()
+		lo, hi int64 // the UNIX seconds offset applies from and until
+		offset uint64
+	)
+
+	// Start with an empty span so the first time searches.
+	lo, hi = 1, 0
+
+	for i := range src {
+		sec := src[i].Unix()
+
+		if sec < lo || sec >= hi {
+			lo, hi, offset = lt.utcSpan(sec)
+		}
+
+		dst[i] = TAI64N{
+			Seconds:     uint64(sec + int64(TAI64OriginalBase+offset)),
+			Nanoseconds: uint32(src[i].Nanosecond()),
+		}
+	}
+}
+
+// Return the span of UNIX seconds around sec over which the offset
+// between UTC and TAI is the same, and that offset.
+func (lt *leapTable) utcSpan(sec int64) (lo, hi int64, offset uint64) {
+	i := sort.Search(len(lt.utc), func(i int) bool {
+		return lt.utc[i] > sec
+	})
+
+	lo, hi = math.MinInt64, math.MaxInt64
+
+	if i > 0 {
+		lo, offset = lt.utc[i-1], lt.offset[i-1]
+	}
+
+	if i < len(lt.utc) {
+		hi = lt.utc[i]
+	}
+
+	return lo, hi, offset
+}
+
+// Convert each of src into dst, which must be at least as long, as Time
+// does. As with FromTimes, sorted input converts fastest.
+func ToTimes(dst []time.Time, src []TAI64N) {
+	dst = dst[:len(src)]
+
+	var (
+		lt     = currentLeaps()
+		lo, hi uint64 // the TAI64 seconds offset applies strictly between
+		offset uint64
+	)
+
+	for i := range src {
+		secs := src[i].Seconds
+
+		if secs <= lo || secs >= hi {
+			var ok bool
+
+			lo, hi, offset, ok = lt.taiSpan(secs)
+
+			// Leap seconds and the start of the table are converted
+			// one at a time.
+			if !ok {
+				dst[i] = src[i].Time()
+				continue
+			}
+		}
+
+		dst[i] = time.Unix(int64(secs-TAI64OriginalBase-offset), int64(src[i].Nanoseconds)).UTC()
+	}
+}
+
+// Return the span of TAI64 seconds strictly between leap moments that
+// secs falls within, and the offset over it. ok is false if secs is the
+// second of a leap moment itself.
+func (lt *leapTable) taiSpan(secs uint64) (lo, hi, offset uint64, ok bool) {
+	i := sort.Search(len(lt.tai), func(i int) bool {
+		return lt.tai[i] > secs
+	}) - 1
+
+	lo, hi = 0, math.MaxUint64
+
+	if i >= 0 {
+		if secs == lt.tai[i] {
+			return 0, 0, 0, false
+		}
+
+		lo, offset = lt.tai[i], lt.offset[i]
+	}
+
+	if i+1 < len(lt.tai) {
+		hi = lt.tai[i+1]
+	}
+
+	return lo, hi, offset, true
+}
+
+// Write each of src in it's canonical binary format, one after another,
+// to dst, which must hold at least StorageSize bytes for each.
+func EncodeStorage(dst []byte, src []TAI64N) {
+	dst = dst[:len(src)*StorageSize]
+
+	for i := range src {
+		b := dst[i*StorageSize : (i+1)*StorageSize]
+
+		binary.BigEndian.PutUint64(b, src[i].Seconds)
+		binary.BigEndian.PutUint32(b[8:], src[i].Nanoseconds)
+	}
+}
+
+// Read the values written by EncodeStorage from src into dst, which must
+// be at least len(src)/StorageSize long.
+func DecodeStorage(dst []TAI64N, src []byte) {
+	dst = dst[:len(src)/StorageSize]
+
+	for i := range dst {
+		b := src[i*StorageSize : (i+1)*StorageSize]
+
+		dst[i] = TAI64N{
+			Seconds:     binary.BigEndian.Uint64(b),
+			Nanoseconds: binary.BigEndian.Uint32(b[8:]),
+		}
+	}
+}
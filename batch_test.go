@@ -0,0 +1,208 @@
+package tai64n
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Times spread from before the table to after it, including each leap
+// second and the seconds either side of it.
+func batchTimes() []time.Time {
+	var times []time.Time
+
+	for _, ls := range AllLeapSeconds {
+		for _, d := range []time.Duration{-time.Hour, -1500 * time.Millisecond, -time.Nanosecond, 0, time.Second} {
+			times = append(times, ls.Threshold.Add(d))
+		}
+	}
+
+	times = append(times,
+		time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(0, 0),
+		time.Date(2030, time.January, 1, 0, 0, 0, 5, time.UTC),
+	)
+
+	return times
+}
+
+func batchMoments() []TAI64N {
+	var moments []TAI64N
+
+	for _, t := range batchTimes() {
+		moments = append(moments, *FromTime(t))
+	}
+
+	for _, lm := range AllLeapMoments {
+		moments = append(moments, *lm.Moment, *lm.Moment.Add(999999999))
+	}
+
+	return moments
+}
+
+func TestFromTimes(t *testing.T) {
+	times := batchTimes()
+
+	rand.New(rand.NewSource(1)).Shuffle(len(times), func(i, j int) {
+		times[i], times[j] = times[j], times[i]
+	})
+
+	dst := make([]TAI64N, len(times))
+
+	FromTimes(dst, times)
+
+	for i, tm := range times {
+		assert.Equal(t, *FromTime(tm), dst[i], "%s", tm)
+	}
+}
+
+func TestToTimes(t *testing.T) {
+	moments := batchMoments()
+
+	rand.New(rand.NewSource(1)).Shuffle(len(moments), func(i, j int) {
+		moments[i], moments[j] = moments[j], moments[i]
+	})
+
+	dst := make([]time.Time, len(moments))
+
+	ToTimes(dst, moments)
+
+	for i := range moments {
+		assert.Equal(t, moments[i].Time(), dst[i], "%s", moments[i].Label())
+	}
+}
+
+func TestBatchShortDst(t *testing.T) {
+	assert.Panics(t, func() {
+		FromTimes(make([]TAI64N, 1), batchTimes())
+	})
+
+	assert.Panics(t, func() {
+		ToTimes(make([]time.Time, 1), batchMoments())
+	})
+}
+
+func TestEncodeStorage(t *testing.T) {
+	moments := batchMoments()
+	buf := make([]byte, len(moments)*StorageSize)
+
+	EncodeStorage(buf, moments)
+
+	for i := range moments {
+		var one [StorageSize]byte
+
+		moments[i].WriteStorage(one[:])
+
+		assert.Equal(t, one[:], buf[i*StorageSize:(i+1)*StorageSize])
+	}
+
+	decoded := make([]TAI64N, len(moments))
+
+	DecodeStorage(decoded, buf)
+
+	assert.Equal(t, moments, decoded)
+}
+
+// A day of sorted times a second apart, as when converting a log
+const benchBatch = 86400
+
+func benchSortedTimes() []time.Time {
+	times := make([]time.Time, benchBatch)
+
+	for i := range times {
+		times[i] = benchRecent.Add(time.Duration(i) * time.Second)
+	}
+
+	return times
+}
+
+func BenchmarkFromTime(b *testing.B) {
+	times := benchSortedTimes()
+	dst := make([]TAI64N, len(times))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j, t := range times {
+			dst[j] = *FromTime(t)
+		}
+	}
+
+	b.ReportMetric(float64(b.N*len(times))/b.Elapsed().Seconds(), "times/s")
+}
+
+func BenchmarkFromTimes(b *testing.B) {
+	times := benchSortedTimes()
+	dst := make([]TAI64N, len(times))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		FromTimes(dst, times)
+	}
+
+	b.ReportMetric(float64(b.N*len(times))/b.Elapsed().Seconds(), "times/s")
+}
+
+func BenchmarkTime(b *testing.B) {
+	moments := make([]TAI64N, benchBatch)
+	FromTimes(moments, benchSortedTimes())
+
+	dst := make([]time.Time, len(moments))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j := range moments {
+			dst[j] = moments[j].Time()
+		}
+	}
+
+	b.ReportMetric(float64(b.N*len(moments))/b.Elapsed().Seconds(), "times/s")
+}
+
+func BenchmarkToTimes(b *testing.B) {
+	moments := make([]TAI64N, benchBatch)
+	FromTimes(moments, benchSortedTimes())
+
+	dst := make([]time.Time, len(moments))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ToTimes(dst, moments)
+	}
+
+	b.ReportMetric(float64(b.N*len(moments))/b.Elapsed().Seconds(), "times/s")
+}
+
+func BenchmarkEncodeStorage(b *testing.B) {
+	moments := make([]TAI64N, benchBatch)
+	FromTimes(moments, benchSortedTimes())
+
+	buf := make([]byte, len(moments)*StorageSize)
+
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		EncodeStorage(buf, moments)
+	}
+}
+
+func BenchmarkDecodeStorage(b *testing.B) {
+	moments := make([]TAI64N, benchBatch)
+	FromTimes(moments, benchSortedTimes())
+
+	buf := make([]byte, len(moments)*StorageSize)
+	EncodeStorage(buf, moments)
+
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		DecodeStorage(moments, buf)
+	}
+}
@@ -0,0 +1,71 @@
+package tai64n
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Returned when a BSON value can't be read as a moment
+var ErrInvalidBSON = errors.New("tai64n: invalid BSON time")
+
+// The user defined BSON binary subtype used for moments, which hold the
+// 12 byte storage format.
+const BSONSubtype = 0x80
+
+// Encode as a BSON binary value of subtype BSONSubtype holding the 12
+// byte storage format, which sorts in time order and keeps leap seconds.
+func (tai TAI64N) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	buf := make([]byte, 17)
+
+	binary.LittleEndian.PutUint32(buf, 12)
+	buf[4] = BSONSubtype
+
+	tai.WriteStorage(buf[5:])
+
+	return bsontype.Binary, buf, nil
+}
+
+// Decode a BSON binary value written by MarshalBSONValue, a string
+// holding a label or RFC3339 time, or a BSON datetime.
+func (tai *TAI64N) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.Binary:
+		if len(data) != 17 || binary.LittleEndian.Uint32(data) != 12 || data[4] != BSONSubtype {
+			return ErrInvalidBSON
+		}
+
+		tai.ReadStorage(data[5:])
+
+		return nil
+
+	case bsontype.String:
+		if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data)-4 || data[len(data)-1] != 0 {
+			return ErrInvalidBSON
+		}
+
+		parsed, err := parseText(string(data[4 : len(data)-1]))
+		if err != nil {
+			return err
+		}
+
+		*tai = parsed
+
+		return nil
+
+	case bsontype.DateTime:
+		if len(data) != 8 {
+			return ErrInvalidBSON
+		}
+
+		ms := int64(binary.LittleEndian.Uint64(data))
+
+		*tai = *FromTime(time.Unix(ms/1e3, ms%1e3*1e6))
+
+		return nil
+	}
+
+	return ErrInvalidBSON
+}
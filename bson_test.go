@@ -0,0 +1,80 @@
+package tai64n
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type bsonDoc struct {
+	Time TAI64N `bson:"time"`
+}
+
+func TestBSON(t *testing.T) {
+	moment := testMoment.Add(500 * time.Millisecond)
+
+	data, err := bson.Marshal(bsonDoc{*moment})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"1c000000"+"0574696d6500"+"0c00000080"+"4000000053618ea31dcd6500"+"00",
+		hex.EncodeToString(data))
+
+	var doc bsonDoc
+
+	err = bson.Unmarshal(data, &doc)
+	require.NoError(t, err)
+
+	assert.True(t, doc.Time.Equal(moment))
+}
+
+func TestBSONLeapSecond(t *testing.T) {
+	leap := AllLeapMoments[len(AllLeapMoments)-1].Moment
+
+	data, err := bson.Marshal(bsonDoc{*leap})
+	require.NoError(t, err)
+
+	var doc bsonDoc
+
+	err = bson.Unmarshal(data, &doc)
+	require.NoError(t, err)
+
+	assert.True(t, doc.Time.Equal(leap))
+}
+
+func TestUnmarshalBSONAlternatives(t *testing.T) {
+	moment := testMoment.Add(500 * time.Millisecond)
+
+	for _, v := range []interface{}{
+		moment.Label(),
+		moment.String(),
+		moment.Time(),
+	} {
+		data, err := bson.Marshal(bson.M{"time": v})
+		require.NoError(t, err)
+
+		var doc bsonDoc
+
+		err = bson.Unmarshal(data, &doc)
+		require.NoError(t, err)
+
+		assert.True(t, doc.Time.Equal(moment), "%v", v)
+	}
+
+	for _, v := range []interface{}{
+		"@40",
+		"yesterday",
+		int32(12),
+	} {
+		data, err := bson.Marshal(bson.M{"time": v})
+		require.NoError(t, err)
+
+		var doc bsonDoc
+
+		assert.Error(t, bson.Unmarshal(data, &doc), "%v", v)
+	}
+}
@@ -0,0 +1,221 @@
+package tai64n
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Returned when CBOR data isn't an extended time this package can read
+var ErrInvalidCBOR = errors.New("tai64n: invalid CBOR time")
+
+// The CBOR tag for extended time (RFC 9581)
+const CBORTagExtendedTime = 1001
+
+// Keys of the extended time map and values of it's time scale
+const (
+	cborKeySeconds   = 1
+	cborKeyTimeScale = -1
+	cborKeyMillis    = -3
+	cborKeyMicros    = -6
+	cborKeyNanos     = -9
+
+	cborScaleUTC = 0
+	cborScaleTAI = 1
+)
+
+const (
+	cborUint  = 0
+	cborNint  = 1
+	cborMap   = 5
+	cborTag   = 6
+	cborShort = 24
+)
+
+// Encode as a CBOR extended time (tag 1001) on the TAI time scale, whose
+// seconds are counted from 1970-01-01 00:00:00 TAI, as PTP does, so no
+// leap second table is needed to read it back. The nanoseconds are left
+// out when zero.
+func (tai TAI64N) MarshalCBOR() ([]byte, error) {
+	entries := uint64(2)
+
+	if tai.Nanoseconds != 0 {
+		entries++
+	}
+
+	buf := make([]byte, 0, 24)
+
+	buf = cborAppendHead(buf, cborTag, CBORTagExtendedTime)
+	buf = cborAppendHead(buf, cborMap, entries)
+
+	// Keys are in the deterministic order: 1, -1, -9.
+	buf = cborAppendInt(buf, cborKeySeconds)
+	buf = cborAppendInt(buf, int64(tai.Seconds-TAI64OriginalBase))
+	buf = cborAppendInt(buf, cborKeyTimeScale)
+	buf = cborAppendInt(buf, cborScaleTAI)
+
+	if tai.Nanoseconds != 0 {
+		buf = cborAppendInt(buf, cborKeyNanos)
+		buf = cborAppendInt(buf, int64(tai.Nanoseconds))
+	}
+
+	return buf, nil
+}
+
+// Decode a CBOR extended time (tag 1001) with integer seconds on either
+// the UTC or TAI time scale, and a fraction in milli, micro or
+// nanoseconds. UTC times are converted using the leap second table.
+func (tai *TAI64N) UnmarshalCBOR(data []byte) error {
+	r := &cborReader{buf: data}
+
+	if major, tag := r.head(); major != cborTag || tag != CBORTagExtendedTime {
+		return ErrInvalidCBOR
+	}
+
+	major, entries := r.head()
+	if major != cborMap || entries > uint64(len(data)) {
+		return ErrInvalidCBOR
+	}
+
+	var (
+		secs, frac  int64
+		unit        int64 = 1
+		scale       int64 = cborScaleUTC
+		seen, fracs int
+	)
+
+	for i := uint64(0); i < entries && r.err == nil; i++ {
+		key, val := r.int(), r.int()
+
+		switch key {
+		case cborKeySeconds:
+			secs = val
+			seen++
+		case cborKeyTimeScale:
+			scale = val
+		case cborKeyMillis:
+			frac, unit = val, 1e6
+			fracs++
+		case cborKeyMicros:
+			frac, unit = val, 1e3
+			fracs++
+		case cborKeyNanos:
+			frac, unit = val, 1
+			fracs++
+		default:
+			return ErrInvalidCBOR
+		}
+	}
+
+	if r.err != nil || len(r.buf) != 0 || seen != 1 || fracs > 1 {
+		return ErrInvalidCBOR
+	}
+
+	if frac < 0 || frac >= 1e9/unit {
+		return ErrInvalidCBOR
+	}
+
+	nanos := uint32(frac * unit)
+
+	switch scale {
+	case cborScaleTAI:
+		*tai = TAI64N{
+			Seconds:     TAI64OriginalBase + uint64(secs),
+			Nanoseconds: nanos,
+		}
+	case cborScaleUTC:
+		*tai = *FromTime(time.Unix(secs, int64(nanos)))
+	default:
+		return ErrInvalidCBOR
+	}
+
+	return nil
+}
+
+func cborAppendHead(buf []byte, major byte, v uint64) []byte {
+	major <<= 5
+
+	switch {
+	case v < cborShort:
+		return append(buf, major|byte(v))
+	case v <= math.MaxUint8:
+		return append(buf, major|cborShort, byte(v))
+	case v <= math.MaxUint16:
+		return append(buf, major|cborShort+1, byte(v>>8), byte(v))
+	case v <= math.MaxUint32:
+		return append(buf, major|cborShort+2, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		return append(buf, major|cborShort+3,
+			byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+			byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+}
+
+func cborAppendInt(buf []byte, n int64) []byte {
+	if n < 0 {
+		return cborAppendHead(buf, cborNint, uint64(-1-n))
+	}
+
+	return cborAppendHead(buf, cborUint, uint64(n))
+}
+
+// Reads the definite length items used by extended times. The first
+// error is kept in err, after which zeros are returned.
+type cborReader struct {
+	buf []byte
+	err error
+}
+
+func (r *cborReader) head() (major byte, v uint64) {
+	if r.err != nil || len(r.buf) == 0 {
+		r.err = ErrInvalidCBOR
+		return 0, 0
+	}
+
+	major, info := r.buf[0]>>5, r.buf[0]&0x1f
+	r.buf = r.buf[1:]
+
+	if info < cborShort {
+		return major, uint64(info)
+	}
+
+	if info > cborShort+3 {
+		r.err = ErrInvalidCBOR
+		return 0, 0
+	}
+
+	n := 1 << (info - cborShort)
+
+	if len(r.buf) < n {
+		r.err = ErrInvalidCBOR
+		return 0, 0
+	}
+
+	for _, b := range r.buf[:n] {
+		v = v<<8 | uint64(b)
+	}
+
+	r.buf = r.buf[n:]
+
+	return major, v
+}
+
+func (r *cborReader) int() int64 {
+	major, v := r.head()
+
+	if v > math.MaxInt64 {
+		r.err = ErrInvalidCBOR
+		return 0
+	}
+
+	switch major {
+	case cborUint:
+		return int64(v)
+	case cborNint:
+		return -1 - int64(v)
+	}
+
+	r.err = ErrInvalidCBOR
+
+	return 0
+}
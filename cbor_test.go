@@ -0,0 +1,89 @@
+package tai64n
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalCBOR(t *testing.T) {
+	data, err := testMoment.MarshalCBOR()
+	require.NoError(t, err)
+
+	assert.Equal(t, "d903e9a2011a53618ea32001", hex.EncodeToString(data))
+
+	data, err = testMoment.Add(500 * time.Millisecond).MarshalCBOR()
+	require.NoError(t, err)
+
+	assert.Equal(t, "d903e9a3011a53618ea32001281a1dcd6500", hex.EncodeToString(data))
+
+	before := TAI64N{Seconds: TAI64OriginalBase - 1}
+
+	data, err = before.MarshalCBOR()
+	require.NoError(t, err)
+
+	assert.Equal(t, "d903e9a201202001", hex.EncodeToString(data))
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	for _, tc := range []struct {
+		data string
+		want *TAI64N
+	}{
+		{"d903e9a2011a53618ea32001", testMoment},
+		{"d903e9a3011a53618ea32001281a1dcd6500", testMoment.Add(500 * time.Millisecond)},
+		{"d903e9a22001011a53618ea3", testMoment},
+		{"d903e9a201202001", &TAI64N{Seconds: TAI64OriginalBase - 1}},
+
+		// UTC, with the fraction in nanoseconds and in milliseconds
+		{"d903e9a1011a53618e80", testMoment},
+		{"d903e9a2011a53618e80281a1dcd6500", testMoment.Add(500 * time.Millisecond)},
+		{"d903e9a3011a53618e80200022187d", testMoment.Add(125 * time.Millisecond)},
+	} {
+		var tai TAI64N
+
+		err := tai.UnmarshalCBOR(unhex(t, tc.data))
+		require.NoError(t, err, tc.data)
+
+		assert.True(t, tai.Equal(tc.want), tc.data)
+	}
+}
+
+func TestUnmarshalCBORRejects(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"1a53618ea3",
+		"c11a53618ea3",
+		"d903e9a0",
+		"d903e9a12001",
+		"d903e9a2011a53618ea3",
+		"d903e9a2011a53618ea32002",
+		"d903e9a2011a53618ea3281a3b9aca00",
+		"d903e9a2011a53618ea30201",
+		"d903e9a1011a53618ea300",
+		"d903e9bf011a53618ea3ff",
+	} {
+		var tai TAI64N
+
+		assert.Equal(t, ErrInvalidCBOR, tai.UnmarshalCBOR(unhex(t, data)), data)
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	for _, lm := range AllLeapMoments {
+		moment := lm.Moment.Add(time.Nanosecond)
+
+		data, err := moment.MarshalCBOR()
+		require.NoError(t, err)
+
+		var tai TAI64N
+
+		err = tai.UnmarshalCBOR(data)
+		require.NoError(t, err)
+
+		assert.True(t, tai.Equal(moment))
+	}
+}
@@ -0,0 +1,73 @@
+package tai64n
+
+import (
+	"errors"
+	"time"
+)
+
+// Returned by FromCivil for a second 60 that isn't a leap second.
+var ErrNoLeapSecond = errors.New("tai64n: no leap second at that time")
+
+// A moment broken down into it's UTC calendar and clock fields.
+type Civil struct {
+	Year       int
+	Month      time.Month
+	Day        int
+	Hour       int
+	Minute     int
+	Second     int // 0-59, or 60 during a leap second
+	Nanosecond int
+	Weekday    time.Weekday
+	YearDay    int
+	IsLeap     bool // the moment is within a leap second
+}
+
+// Break the moment down into it's UTC fields, in a single lookup of the
+// leap second table.
+func (t *TAI64N) Civil() Civil {
+	utc, leap := t.UTC()
+
+	c := Civil{
+		Nanosecond: utc.Nanosecond(),
+		Weekday:    utc.Weekday(),
+		YearDay:    utc.YearDay(),
+		IsLeap:     leap,
+	}
+
+	c.Year, c.Month, c.Day = utc.Date()
+	c.Hour, c.Minute, c.Second = utc.Clock()
+
+	// A leap second is shown as a repeat of the second before it.
+	if leap {
+		c.Second++
+	}
+
+	return c
+}
+
+// Convert from UTC fields. Out of range fields are normalised as by
+// time.Date, except for second 60, which is only accepted as the leap
+// second at the end of a day the table has one for. Weekday, YearDay
+// and IsLeap are ignored.
+func FromCivil(c Civil) (*TAI64N, error) {
+	if c.Second != 60 {
+		return FromTime(time.Date(c.Year, c.Month, c.Day,
+			c.Hour, c.Minute, c.Second, c.Nanosecond, time.UTC)), nil
+	}
+
+	if c.Nanosecond < 0 || c.Nanosecond >= 1e9 {
+		return nil, ErrNoLeapSecond
+	}
+
+	// The leap second comes just before the threshold, so it is the
+	// repeat of the second 59 before it.
+	t := FromTime(time.Date(c.Year, c.Month, c.Day,
+		c.Hour, c.Minute, 59, 0, time.UTC)).Add(time.Second)
+
+	lm := leapSecondAt(t)
+	if lm == nil {
+		return nil, ErrNoLeapSecond
+	}
+
+	return t.Add(time.Duration(c.Nanosecond)), nil
+}
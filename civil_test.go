@@ -0,0 +1,110 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCivil(t *testing.T) {
+	tm := time.Date(2014, time.May, 1, 13, 14, 15, 16, time.UTC)
+
+	c := FromTime(tm).Civil()
+
+	assert.Equal(t, Civil{
+		Year:       2014,
+		Month:      time.May,
+		Day:        1,
+		Hour:       13,
+		Minute:     14,
+		Second:     15,
+		Nanosecond: 16,
+		Weekday:    time.Thursday,
+		YearDay:    121,
+	}, c)
+}
+
+func TestCivilAtLeap(t *testing.T) {
+	before := FromTime(time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC))
+
+	leap := before.Add(1500 * time.Millisecond).Civil()
+
+	assert.Equal(t, 2016, leap.Year)
+	assert.Equal(t, time.December, leap.Month)
+	assert.Equal(t, 31, leap.Day)
+	assert.Equal(t, 23, leap.Hour)
+	assert.Equal(t, 59, leap.Minute)
+	assert.Equal(t, 60, leap.Second)
+	assert.Equal(t, 5e8, float64(leap.Nanosecond))
+	assert.Equal(t, time.Saturday, leap.Weekday)
+	assert.Equal(t, 366, leap.YearDay)
+	assert.True(t, leap.IsLeap)
+
+	after := before.Add(2 * time.Second).Civil()
+
+	assert.Equal(t, 2017, after.Year)
+	assert.Equal(t, 0, after.Second)
+	assert.False(t, after.IsLeap)
+}
+
+func TestFromCivil(t *testing.T) {
+	tm := time.Date(2014, time.May, 1, 13, 14, 15, 16, time.UTC)
+	tai := FromTime(tm)
+
+	c, err := FromCivil(tai.Civil())
+	require.NoError(t, err)
+
+	assert.True(t, c.Equal(tai))
+
+	for _, lm := range AllLeapMoments[1:] {
+		moment := lm.Moment.Add(250 * time.Millisecond)
+
+		c, err := FromCivil(moment.Civil())
+		require.NoError(t, err)
+
+		assert.True(t, c.Equal(moment), "%s", moment)
+	}
+}
+
+func TestFromCivilRejectsSecond60(t *testing.T) {
+	_, err := FromCivil(Civil{
+		Year: 2014, Month: time.May, Day: 1,
+		Hour: 23, Minute: 59, Second: 60,
+	})
+
+	assert.Equal(t, ErrNoLeapSecond, err)
+
+	_, err = FromCivil(Civil{
+		Year: 2016, Month: time.December, Day: 31,
+		Hour: 12, Minute: 59, Second: 60,
+	})
+
+	assert.Equal(t, ErrNoLeapSecond, err)
+}
+
+func TestStringFraction(t *testing.T) {
+	tm := time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, ns := range []int{0, 1, 50, 123456789, 500000000} {
+		n := FromTime(tm.Add(time.Duration(ns)))
+
+		assert.Equal(t, tm.Add(time.Duration(ns)).Format(time.RFC3339Nano), n.String())
+	}
+
+	leap := FromTime(time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC)).
+		Add(1050 * time.Millisecond)
+
+	assert.Equal(t, "2016-12-31T23:59:60.05Z", leap.String())
+}
+
+func TestStringFormat(t *testing.T) {
+	for s, tm := range map[string]time.Time{
+		"0033-04-03T15:00:00Z":           time.Date(33, time.April, 3, 15, 0, 0, 0, time.UTC),
+		"2014-05-01T00:00:00.031782198Z": time.Date(2014, time.May, 1, 0, 0, 0, 31782198, time.UTC),
+		"2014-05-01T00:00:00.0317Z":      time.Date(2014, time.May, 1, 0, 0, 0, 31700000, time.UTC),
+	} {
+		assert.Equal(t, s, FromTime(tm).String())
+	}
+}
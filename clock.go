@@ -0,0 +1,126 @@
+package tai64n
+
+import (
+	"sync"
+	"time"
+)
+
+// A source of the current moment. Code that takes a Clock rather than
+// calling Now directly can be tested against a FakeClock.
+type Clock interface {
+	Now() *TAI64N
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() *TAI64N {
+	return Now()
+}
+
+// The Clock that reads the system time via Now.
+var SystemClock Clock = systemClock{}
+
+// A Clock that can say when it reaches a moment. Ticker, Timer and
+// LeapNotifier wait on such a clock rather than in real time, so a
+// FakeClock drives them.
+type AlarmClock interface {
+	Clock
+
+	// Return a channel that receives the clock's moment once it has
+	// reached t.
+	Alarm(t *TAI64N) <-chan *TAI64N
+}
+
+// A Clock that only moves when told to.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    TAI64N
+	alarms []fakeAlarm
+}
+
+type fakeAlarm struct {
+	at TAI64N
+	c  chan *TAI64N
+}
+
+// Create a FakeClock stopped at start.
+func NewFakeClock(start *TAI64N) *FakeClock {
+	return &FakeClock{now: *start}
+}
+
+// Return the moment the clock is stopped at.
+func (c *FakeClock) Now() *TAI64N {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now
+
+	return &now
+}
+
+// Move the clock by dur, which may be negative. The clock counts TAI
+// seconds, so advancing across a leap second lands on it.
+func (c *FakeClock) Advance(dur time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = *c.now.Add(dur)
+	c.ring()
+}
+
+// Stop the clock at the given moment.
+func (c *FakeClock) Set(t *TAI64N) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = *t
+	c.ring()
+}
+
+// Return a channel that receives the clock's moment once it has been
+// advanced or set to t or later.
+func (c *FakeClock) Alarm(t *TAI64N) <-chan *TAI64N {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan *TAI64N, 1)
+
+	c.alarms = append(c.alarms, fakeAlarm{*t, ch})
+	c.ring()
+
+	return ch
+}
+
+// Send on the channels of the alarms that are due, with c locked
+func (c *FakeClock) ring() {
+	pending := c.alarms[:0]
+
+	for _, a := range c.alarms {
+		if a.at.After(&c.now) {
+			pending = append(pending, a)
+			continue
+		}
+
+		now := c.now
+		a.c <- &now
+	}
+
+	c.alarms = pending
+}
+
+// A Clock that reports the moments of another Clock shifted by a fixed
+// offset.
+type OffsetClock struct {
+	Clock  Clock
+	Offset time.Duration
+}
+
+// Create an OffsetClock that runs offset ahead of clock.
+func NewOffsetClock(clock Clock, offset time.Duration) *OffsetClock {
+	return &OffsetClock{clock, offset}
+}
+
+// Return the moment of the underlying clock plus the offset.
+func (c *OffsetClock) Now() *TAI64N {
+	return c.Clock.Now().Add(c.Offset)
+}
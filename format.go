@@ -2,6 +2,7 @@ package tai64n
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -9,39 +10,34 @@ import (
 // falls on a leap second, the displayed value will be that of the
 // leap second as the 60th second of the day.
 func (t *TAI64N) Date() (year int, month time.Month, day int) {
-	lm := nearestLeapMoment(t)
+	c := t.Civil()
 
-	if t.Equal(lm.Moment) {
-		prev := lm.LeapSecond.Threshold.Add(-1 * time.Second)
-		return prev.Date()
-	}
-
-	return t.Time().Date()
+	return c.Year, c.Month, c.Day
 }
 
 // Calculate the hour, minute, and second of this moment. If the moment
 // falls on a leap second, the displayed value will be that of the
 // leap second as the 60th second of the day.
 func (t *TAI64N) Clock() (hour, min, sec int) {
-	lm := nearestLeapMoment(t)
-
-	if t.Equal(lm.Moment) {
-		prev := lm.LeapSecond.Threshold.Add(-1 * time.Second)
-		hour, min, sec := prev.Clock()
-		return hour, min, sec + 1
-	}
-
-	return t.Time().Clock()
+	c := t.Civil()
 
+	return c.Hour, c.Minute, c.Second
 }
 
-// Render the moment as a RFC3339Nano format
+// Render the moment as a RFC3339Nano format, as time.Time does: the year
+// is zero padded to 4 digits and the fraction of the second is 9 digits
+// with trailing zeros dropped, or left out if zero. A leap second is
+// rendered as second 60.
 func (t *TAI64N) String() string {
-	year, month, day := t.Date()
-	hour, minute, sec := t.Clock()
+	c := t.Civil()
+
+	s := fmt.Sprintf("%04d-%02d-%02dT%02d:%02d:%02d",
+		c.Year, c.Month, c.Day,
+		c.Hour, c.Minute, c.Second)
+
+	if c.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", c.Nanosecond), "0")
+	}
 
-	return fmt.Sprintf("%4d-%02d-%02dT%02d:%02d:%02d.%dZ",
-		year, month, day,
-		hour, minute, sec,
-		t.Nanoseconds)
+	return s + "Z"
 }
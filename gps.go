@@ -0,0 +1,77 @@
+package tai64n
+
+import "time"
+
+// GPS time runs at a fixed offset from TAI, it has no leap seconds of it's
+// own. It's epoch is 1980-01-06 00:00:00 UTC, when TAI-GPS was 19s.
+const (
+	TAIMinusGPS = 19 * time.Second
+
+	// The number of seconds in a GPS week
+	GPSWeekSeconds = 7 * 24 * 60 * 60
+
+	// The width of the week number broadcast in the legacy navigation
+	// message and the one in the modernized CNAV message.
+	GPSWeekBits10 = 10
+	GPSWeekBits13 = 13
+)
+
+// The TAI64 seconds at the GPS epoch
+var gpsEpoch = uint64(time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC).Unix()) +
+	TAI64OriginalBase + uint64(TAIMinusGPS/time.Second)
+
+// Convert from a number of seconds and nanoseconds since the GPS epoch
+func FromGPSSeconds(secs int64, nsecs uint32) *TAI64N {
+	return &TAI64N{
+		Seconds:     uint64(int64(gpsEpoch) + secs),
+		Nanoseconds: nsecs,
+	}
+}
+
+// Return the number of seconds and nanoseconds since the GPS epoch. The
+// seconds are negative for moments before it.
+func (tai *TAI64N) GPSSeconds() (secs int64, nsecs uint32) {
+	return int64(tai.Seconds - gpsEpoch), tai.Nanoseconds
+}
+
+// Convert from a full GPS week number and time of week. Week numbers
+// truncated by the receiver must first be resolved with ResolveGPSWeek.
+func FromGPS(week int, tow time.Duration) *TAI64N {
+	return FromGPSSeconds(int64(week)*GPSWeekSeconds, 0).Add(tow)
+}
+
+// Return the full GPS week number and the time into that week
+func (tai *TAI64N) ToGPS() (week int, tow time.Duration) {
+	secs, nsecs := tai.GPSSeconds()
+
+	week = int(secs / GPSWeekSeconds)
+	rem := secs % GPSWeekSeconds
+
+	if rem < 0 {
+		week--
+		rem += GPSWeekSeconds
+	}
+
+	return week, time.Duration(rem)*time.Second + time.Duration(nsecs)
+}
+
+// Resolve a week number truncated to the given number of bits, as it
+// is broadcast, to the full week number closest to the moment ref. ref
+// is usually the current moment, and must be within half a rollover
+// period (about 9.8 years for 10 bits) of the week being resolved.
+func ResolveGPSWeek(week, bits int, ref *TAI64N) int {
+	var (
+		period     = 1 << uint(bits)
+		refWeek, _ = ref.ToGPS()
+		full       = refWeek - refWeek%period + week%period
+	)
+
+	switch {
+	case full-refWeek > period/2:
+		full -= period
+	case refWeek-full > period/2:
+		full += period
+	}
+
+	return full
+}
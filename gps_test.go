@@ -0,0 +1,66 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGPSEpoch(t *testing.T) {
+	epoch := FromTime(time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC))
+
+	secs, nsecs := epoch.GPSSeconds()
+	assert.Equal(t, int64(0), secs)
+	assert.Equal(t, uint32(0), nsecs)
+
+	week, tow := epoch.ToGPS()
+	assert.Equal(t, 0, week)
+	assert.Equal(t, time.Duration(0), tow)
+}
+
+func TestGPSLeapSeconds(t *testing.T) {
+	// By 2017 UTC had gained 18 leap seconds since the GPS epoch, which
+	// GPS time does not have.
+	m := FromTime(time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	secs, _ := m.GPSSeconds()
+	utc := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC).Sub(time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, int64(utc/time.Second)+18, secs)
+}
+
+func TestGPSWeek(t *testing.T) {
+	// The second GPS week rollover
+	m := FromTime(time.Date(2019, time.April, 7, 0, 0, 0, 0, time.UTC))
+
+	week, tow := m.ToGPS()
+	assert.Equal(t, 2048, week)
+	assert.Equal(t, 18*time.Second, tow)
+
+	assert.True(t, FromGPS(week, tow).Equal(m))
+
+	n := FromGPS(2048, 18*time.Second+500*time.Millisecond)
+	assert.Equal(t, m.Seconds, n.Seconds)
+	assert.Equal(t, uint32(5e8), n.Nanoseconds)
+}
+
+func TestGPSBeforeEpoch(t *testing.T) {
+	m := FromGPSSeconds(-1, 0)
+
+	week, tow := m.ToGPS()
+	assert.Equal(t, -1, week)
+	assert.Equal(t, (GPSWeekSeconds-1)*time.Second, tow)
+}
+
+func TestResolveGPSWeek(t *testing.T) {
+	ref := FromGPS(2100, 0)
+
+	assert.Equal(t, 2100, ResolveGPSWeek(2100%1024, GPSWeekBits10, ref))
+	assert.Equal(t, 2047, ResolveGPSWeek(1023, GPSWeekBits10, ref))
+	assert.Equal(t, 2100, ResolveGPSWeek(2100, GPSWeekBits13, ref))
+
+	ref = FromGPS(2040, 0)
+
+	assert.Equal(t, 2050, ResolveGPSWeek(2, GPSWeekBits10, ref))
+}
@@ -0,0 +1,133 @@
+package tai64n
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Returned by HLC.Update when a remote timestamp is further ahead of the
+// local clock than the allowed drift.
+var ErrClockDrift = errors.New("tai64n: remote clock is too far ahead")
+
+// A hybrid logical clock timestamp. The physical component is a TAI64N
+// moment and the logical component orders events within that moment.
+type HLCTimestamp struct {
+	Physical TAI64N
+	Logical  uint32
+}
+
+// The size of the binary form of an HLCTimestamp.
+const HLCStorageSize = 16
+
+// Return the value in it's binary format: the physical component in it's
+// canonical binary format followed by the big endian logical component.
+// The encoded values sort bytewise in the same order as the timestamps.
+func (ts *HLCTimestamp) WriteStorage(buf []byte) {
+	ts.Physical.WriteStorage(buf)
+	binary.BigEndian.PutUint32(buf[12:], ts.Logical)
+}
+
+// Update the value from it's binary format
+func (ts *HLCTimestamp) ReadStorage(buf []byte) {
+	ts.Physical.ReadStorage(buf)
+	ts.Logical = binary.BigEndian.Uint32(buf[12:])
+}
+
+// Indicate how the 2 timestamps compare to eachother
+func (ts *HLCTimestamp) Compare(other *HLCTimestamp) TimeComparison {
+	if c := ts.Physical.Compare(&other.Physical); c != Equal {
+		return c
+	}
+
+	switch {
+	case ts.Logical < other.Logical:
+		return Before
+	case ts.Logical > other.Logical:
+		return After
+	default:
+		return Equal
+	}
+}
+
+// Indicated if the called timestamp is before the argument
+func (ts *HLCTimestamp) Before(other *HLCTimestamp) bool {
+	return ts.Compare(other) == Before
+}
+
+// Indicated if the called timestamp is after the argument
+func (ts *HLCTimestamp) After(other *HLCTimestamp) bool {
+	return ts.Compare(other) == After
+}
+
+// A hybrid logical clock. Timestamps it issues stay close to the
+// physical clock while never going backwards, and any timestamp issued
+// after receiving a remote one via Update is after it.
+type HLC struct {
+	clock    Clock
+	maxDrift time.Duration
+
+	mu   sync.Mutex
+	last HLCTimestamp
+}
+
+// Create an HLC reading physical time from clock. Update rejects remote
+// timestamps more than maxDrift ahead of clock; zero allows any drift.
+func NewHLC(clock Clock, maxDrift time.Duration) *HLC {
+	return &HLC{clock: clock, maxDrift: maxDrift}
+}
+
+// Return a timestamp for a local or send event.
+func (h *HLC) Now() HLCTimestamp {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pt := h.clock.Now()
+
+	if pt.After(&h.last.Physical) {
+		h.last = HLCTimestamp{Physical: *pt}
+	} else {
+		h.last.Logical++
+	}
+
+	return h.last
+}
+
+// Merge a timestamp received from a remote clock, returning a timestamp
+// for the receive event which is after both it and any previously
+// issued. The clock is left unchanged if the remote timestamp exceeds
+// the allowed drift, and ErrClockDrift is returned.
+func (h *HLC) Update(remote HLCTimestamp) (HLCTimestamp, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pt := h.clock.Now()
+
+	if h.maxDrift > 0 && remote.Physical.After(pt) && remote.Physical.Sub(pt) > h.maxDrift {
+		return h.last, ErrClockDrift
+	}
+
+	last := h.last
+
+	switch {
+	case pt.After(&last.Physical) && pt.After(&remote.Physical):
+		h.last = HLCTimestamp{Physical: *pt}
+	case last.Physical.Equal(&remote.Physical):
+		h.last.Logical = maxUint32(last.Logical, remote.Logical) + 1
+	case last.Physical.After(&remote.Physical):
+		h.last.Logical++
+	default:
+		h.last = HLCTimestamp{Physical: remote.Physical, Logical: remote.Logical + 1}
+	}
+
+	return h.last, nil
+}
+
+func maxUint32(a, b uint32) uint32 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
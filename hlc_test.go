@@ -0,0 +1,109 @@
+package tai64n
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHLCNow(t *testing.T) {
+	clock := NewFakeClock(FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC)))
+	h := NewHLC(clock, 0)
+
+	t1 := h.Now()
+	t2 := h.Now()
+
+	assert.True(t, t2.After(&t1))
+	assert.Equal(t, t1.Physical, t2.Physical)
+	assert.Equal(t, uint32(1), t2.Logical)
+
+	clock.Advance(time.Second)
+
+	t3 := h.Now()
+
+	assert.True(t, t3.After(&t2))
+	assert.Equal(t, uint32(0), t3.Logical)
+
+	clock.Advance(-time.Minute)
+
+	t4 := h.Now()
+
+	assert.True(t, t4.After(&t3))
+	assert.Equal(t, t3.Physical, t4.Physical)
+}
+
+func TestHLCUpdate(t *testing.T) {
+	start := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+	clock := NewFakeClock(start)
+	h := NewHLC(clock, time.Minute)
+
+	local := h.Now()
+
+	remote := HLCTimestamp{Physical: *start.Add(time.Second), Logical: 5}
+
+	ts, err := h.Update(remote)
+	require.NoError(t, err)
+
+	assert.True(t, ts.After(&remote))
+	assert.True(t, ts.After(&local))
+	assert.Equal(t, uint32(6), ts.Logical)
+
+	ts2, err := h.Update(remote)
+	require.NoError(t, err)
+
+	assert.True(t, ts2.After(&ts))
+
+	clock.Advance(2 * time.Second)
+
+	ts3, err := h.Update(remote)
+	require.NoError(t, err)
+
+	assert.Equal(t, *clock.Now(), ts3.Physical)
+	assert.Equal(t, uint32(0), ts3.Logical)
+}
+
+func TestHLCDrift(t *testing.T) {
+	start := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+	h := NewHLC(NewFakeClock(start), time.Second)
+
+	before := h.Now()
+
+	_, err := h.Update(HLCTimestamp{Physical: *start.Add(time.Hour)})
+	assert.Equal(t, ErrClockDrift, err)
+
+	after := h.Now()
+
+	assert.Equal(t, before.Physical, after.Physical)
+}
+
+func TestHLCStorage(t *testing.T) {
+	start := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+	stamps := []HLCTimestamp{
+		{Physical: *start, Logical: 0},
+		{Physical: *start, Logical: 1},
+		{Physical: *start.Add(1), Logical: 0},
+		{Physical: *start.Add(time.Second), Logical: 0},
+	}
+
+	var prev []byte
+
+	for _, ts := range stamps {
+		buf := make([]byte, HLCStorageSize)
+		ts.WriteStorage(buf)
+
+		var back HLCTimestamp
+		back.ReadStorage(buf)
+
+		assert.Equal(t, ts, back)
+
+		if prev != nil {
+			assert.Equal(t, -1, bytes.Compare(prev, buf))
+		}
+
+		prev = buf
+	}
+}
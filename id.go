@@ -0,0 +1,144 @@
+package tai64n
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Returned by ParseID when the string is not a valid ID.
+var ErrInvalidID = errors.New("tai64n: invalid id")
+
+// A 128 bit identifier whose first 12 bytes are the canonical binary
+// format of the moment it was created at, followed by 4 random or
+// sequence bytes. IDs sort bytewise, and in both string encodings, in
+// the order of their moments, across leap seconds too.
+type ID [16]byte
+
+// The Crockford base32 alphabet, which is in ascii order.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Return a new ID for the current moment.
+func NewID() ID {
+	return NewRandomID(Now())
+}
+
+// Return a new ID for the given moment with a random tail.
+func NewRandomID(t *TAI64N) ID {
+	var id ID
+
+	t.WriteStorage(id[:])
+
+	_, err := rand.Read(id[12:])
+	if err != nil {
+		panic("tai64n: unable to read random bytes: " + err.Error())
+	}
+
+	return id
+}
+
+// Return a new ID for the given moment with seq as it's tail, so that
+// IDs for the same moment sort in the order of seq.
+func NewSequentialID(t *TAI64N, seq uint32) ID {
+	var id ID
+
+	t.WriteStorage(id[:])
+	binary.BigEndian.PutUint32(id[12:], seq)
+
+	return id
+}
+
+// Return the moment embedded in the ID
+func (id ID) Moment() *TAI64N {
+	t := &TAI64N{}
+
+	t.ReadStorage(id[:])
+
+	return t
+}
+
+// Render the ID in the same style as a label, @ followed by 32 hex digits
+func (id ID) String() string {
+	return "@" + strings.ToUpper(hex.EncodeToString(id[:]))
+}
+
+// Render the ID as 26 Crockford base32 digits
+func (id ID) Base32() string {
+	var (
+		buf [26]byte
+		hi  = binary.BigEndian.Uint64(id[:8])
+		lo  = binary.BigEndian.Uint64(id[8:])
+	)
+
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(buf[:])
+}
+
+// Parse either the label style or base32 rendering of an ID
+func ParseID(s string) (ID, error) {
+	var id ID
+
+	switch {
+	case len(s) == 33 && s[0] == '@':
+		buf, err := hex.DecodeString(s[1:])
+		if err != nil {
+			return id, ErrInvalidID
+		}
+
+		copy(id[:], buf)
+	case len(s) == 26:
+		var hi, lo uint64
+
+		for i := 0; i < len(s); i++ {
+			v := crockfordValue(s[i])
+
+			// The first digit only holds the top 3 bits.
+			if v < 0 || (i == 0 && v > 7) {
+				return id, ErrInvalidID
+			}
+
+			hi = hi<<5 | lo>>59
+			lo = lo<<5 | uint64(v)
+		}
+
+		binary.BigEndian.PutUint64(id[:8], hi)
+		binary.BigEndian.PutUint64(id[8:], lo)
+	default:
+		return id, ErrInvalidID
+	}
+
+	return id, nil
+}
+
+// Return the value of a Crockford base32 digit, or -1. Decoding is case
+// insensitive and accepts the usual substitutions for 0 and 1.
+func crockfordValue(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+
+	switch c {
+	case 'O':
+		c = '0'
+	case 'I', 'L':
+		c = '1'
+	}
+
+	return strings.IndexByte(crockford, c)
+}
+
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *ID) UnmarshalText(data []byte) (err error) {
+	*id, err = ParseID(string(data))
+	return err
+}
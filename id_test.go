@@ -0,0 +1,71 @@
+package tai64n
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDMoment(t *testing.T) {
+	m := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+	id := NewRandomID(m)
+
+	assert.True(t, id.Moment().Equal(m))
+	assert.Equal(t, "@4000000053618EA300000000", id.String()[:25])
+}
+
+func TestIDStrings(t *testing.T) {
+	m := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+	id := NewSequentialID(m, 0xdeadbeef)
+
+	assert.Equal(t, "@4000000053618EA300000000DEADBEEF", id.String())
+	assert.Equal(t, "2000000MV1HTHG000003FAVFQF", id.Base32())
+
+	for _, s := range []string{id.String(), id.Base32(), strings.ToLower(id.Base32())} {
+		back, err := ParseID(s)
+		require.NoError(t, err)
+		assert.Equal(t, id, back)
+	}
+}
+
+func TestParseIDInvalid(t *testing.T) {
+	for _, s := range []string{"", "@123", "@4000000053618EA300000000DEADBEEZ", "80000000000000000000000000", "0000000000000000000000000U"} {
+		_, err := ParseID(s)
+		assert.Equal(t, ErrInvalidID, err, s)
+	}
+}
+
+func TestIDOrder(t *testing.T) {
+	leap := FromTime(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC))
+
+	ids := []ID{
+		NewSequentialID(leap.Add(-2*time.Second), 0xffffffff),
+		NewSequentialID(leap.Add(-time.Second), 0),
+		NewSequentialID(leap.Add(-time.Second), 1),
+		NewSequentialID(leap, 0),
+		NewSequentialID(leap.Add(1), 0),
+	}
+
+	for i := 1; i < len(ids); i++ {
+		assert.Equal(t, -1, bytes.Compare(ids[i-1][:], ids[i][:]))
+		assert.True(t, ids[i-1].String() < ids[i].String())
+		assert.True(t, ids[i-1].Base32() < ids[i].Base32())
+	}
+}
+
+func TestIDJSON(t *testing.T) {
+	id := NewID()
+
+	data, err := json.Marshal(id)
+	require.NoError(t, err)
+
+	var back ID
+
+	require.NoError(t, json.Unmarshal(data, &back))
+	assert.Equal(t, id, back)
+}
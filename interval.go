@@ -0,0 +1,162 @@
+package tai64n
+
+import (
+	"sort"
+	"time"
+)
+
+// A half open range of moments, including Start but not End. An interval
+// whose End is not after it's Start is empty.
+type Interval struct {
+	Start TAI64N
+	End   TAI64N
+}
+
+// Create the interval from start up to, but not including, end
+func NewInterval(start, end *TAI64N) Interval {
+	return Interval{*start, *end}
+}
+
+// Indicate if the interval contains no moments
+func (iv Interval) IsEmpty() bool {
+	return !iv.Start.Before(&iv.End)
+}
+
+// Return the TAI seconds between the start and end of the interval,
+// which unlike UTC count any leap seconds within it.
+func (iv Interval) Duration() time.Duration {
+	if iv.IsEmpty() {
+		return 0
+	}
+
+	return iv.End.taiSince(&iv.Start)
+}
+
+// Indicate if the moment is within the interval
+func (iv Interval) Contains(t *TAI64N) bool {
+	return !t.Before(&iv.Start) && t.Before(&iv.End)
+}
+
+// Indicate if every moment of other is within the interval
+func (iv Interval) ContainsInterval(other Interval) bool {
+	if other.IsEmpty() {
+		return true
+	}
+
+	return !other.Start.Before(&iv.Start) && !other.End.After(&iv.End)
+}
+
+// Indicate if the 2 intervals have any moment in common
+func (iv Interval) Overlaps(other Interval) bool {
+	return !iv.Intersect(other).IsEmpty()
+}
+
+// Return the moments common to both intervals, which may be empty
+func (iv Interval) Intersect(other Interval) Interval {
+	return Interval{
+		Start: *maxMoment(&iv.Start, &other.Start),
+		End:   *minMoment(&iv.End, &other.End),
+	}
+}
+
+// Return the interval covering the moments of both, provided they overlap
+// or meet. Otherwise there's a gap and ok is false.
+func (iv Interval) Union(other Interval) (u Interval, ok bool) {
+	switch {
+	case iv.IsEmpty():
+		return other, true
+	case other.IsEmpty():
+		return iv, true
+	case iv.End.Before(&other.Start) || other.End.Before(&iv.Start):
+		return Interval{}, false
+	}
+
+	return Interval{
+		Start: *minMoment(&iv.Start, &other.Start),
+		End:   *maxMoment(&iv.End, &other.End),
+	}, true
+}
+
+func minMoment(a, b *TAI64N) *TAI64N {
+	if b.Before(a) {
+		return b
+	}
+
+	return a
+}
+
+func maxMoment(a, b *TAI64N) *TAI64N {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
+
+// A set of moments made up of intervals. The intervals are kept sorted,
+// with any that overlap or meet merged and empty ones dropped.
+type IntervalSet struct {
+	intervals []Interval
+}
+
+// Create a set covering the given intervals
+func NewIntervalSet(intervals ...Interval) *IntervalSet {
+	s := &IntervalSet{}
+
+	s.Add(intervals...)
+
+	return s
+}
+
+// Add the moments of the given intervals to the set
+func (s *IntervalSet) Add(intervals ...Interval) {
+	all := append(s.intervals, intervals...)
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Start.Before(&all[j].Start)
+	})
+
+	var merged []Interval
+
+	for _, iv := range all {
+		if iv.IsEmpty() {
+			continue
+		}
+
+		if n := len(merged); n > 0 {
+			if u, ok := merged[n-1].Union(iv); ok {
+				merged[n-1] = u
+				continue
+			}
+		}
+
+		merged = append(merged, iv)
+	}
+
+	s.intervals = merged
+}
+
+// Return the normalised intervals of the set, in order
+func (s *IntervalSet) Intervals() []Interval {
+	return append([]Interval(nil), s.intervals...)
+}
+
+// Indicate if the moment is within the set
+func (s *IntervalSet) Contains(t *TAI64N) bool {
+	i := sort.Search(len(s.intervals), func(i int) bool {
+		return t.Before(&s.intervals[i].End)
+	})
+
+	return i < len(s.intervals) && s.intervals[i].Contains(t)
+}
+
+// Return the total TAI seconds covered by the set
+func (s *IntervalSet) Duration() time.Duration {
+	var total time.Duration
+
+	for _, iv := range s.intervals {
+		total += iv.Duration()
+	}
+
+	return total
+}
@@ -0,0 +1,75 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ivBase = FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+func iv(from, to int) Interval {
+	return NewInterval(ivBase.Add(time.Duration(from)*time.Second), ivBase.Add(time.Duration(to)*time.Second))
+}
+
+func TestIntervalContains(t *testing.T) {
+	i := iv(0, 10)
+
+	assert.True(t, i.Contains(ivBase))
+	assert.True(t, i.Contains(ivBase.Add(10*time.Second-1)))
+	assert.False(t, i.Contains(ivBase.Add(10*time.Second)))
+	assert.False(t, i.Contains(ivBase.Add(-1)))
+
+	assert.True(t, i.ContainsInterval(iv(2, 10)))
+	assert.False(t, i.ContainsInterval(iv(2, 11)))
+	assert.True(t, i.ContainsInterval(iv(20, 20)))
+}
+
+func TestIntervalOverlaps(t *testing.T) {
+	assert.True(t, iv(0, 10).Overlaps(iv(9, 20)))
+	assert.False(t, iv(0, 10).Overlaps(iv(10, 20)))
+	assert.False(t, iv(0, 10).Overlaps(iv(5, 5)))
+
+	assert.Equal(t, iv(5, 10), iv(0, 10).Intersect(iv(5, 20)))
+	assert.True(t, iv(0, 10).Intersect(iv(15, 20)).IsEmpty())
+}
+
+func TestIntervalUnion(t *testing.T) {
+	u, ok := iv(0, 10).Union(iv(10, 20))
+	assert.True(t, ok)
+	assert.Equal(t, iv(0, 20), u)
+
+	u, ok = iv(5, 20).Union(iv(0, 10))
+	assert.True(t, ok)
+	assert.Equal(t, iv(0, 20), u)
+
+	_, ok = iv(0, 10).Union(iv(11, 20))
+	assert.False(t, ok)
+}
+
+func TestIntervalDurationCountsLeapSeconds(t *testing.T) {
+	i := NewInterval(
+		FromTime(time.Date(2012, time.June, 30, 23, 59, 0, 0, time.UTC)),
+		FromTime(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	assert.Equal(t, 61*time.Second, i.Duration())
+	assert.Equal(t, time.Duration(0), iv(10, 0).Duration())
+}
+
+func TestIntervalSet(t *testing.T) {
+	s := NewIntervalSet(iv(20, 30), iv(0, 10), iv(5, 15), iv(30, 35), iv(40, 40))
+
+	assert.Equal(t, []Interval{iv(0, 15), iv(20, 35)}, s.Intervals())
+	assert.Equal(t, 30*time.Second, s.Duration())
+
+	assert.True(t, s.Contains(ivBase.Add(14*time.Second)))
+	assert.False(t, s.Contains(ivBase.Add(15*time.Second)))
+	assert.True(t, s.Contains(ivBase.Add(34*time.Second)))
+	assert.False(t, s.Contains(ivBase.Add(35*time.Second)))
+
+	s.Add(iv(14, 21))
+
+	assert.Equal(t, []Interval{iv(0, 35)}, s.Intervals())
+}
@@ -0,0 +1,199 @@
+package tai64n
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Return the first leap second after the moment, or nil if none is in
+// AllLeapSeconds. Append upcoming leap seconds to AllLeapSeconds as they
+// are announced for them to be found.
+func NextLeapSecond(t *TAI64N) *LeapMoment {
+	return nextLeapMoment(currentLeaps().moments, t)
+}
+
+func nextLeapMoment(moments []*LeapMoment, t *TAI64N) *LeapMoment {
+	i := sort.Search(len(moments), func(i int) bool {
+		return moments[i].Moment.After(t)
+	})
+
+	// The first entry of the table is where it starts rather than a leap.
+	if i == 0 {
+		i = 1
+	}
+
+	if i >= len(moments) {
+		return nil
+	}
+
+	return moments[i]
+}
+
+// Which side of a leap second a LeapEvent is on.
+type LeapPhase int
+
+const (
+	// The leap second is about to happen
+	LeapApproaching LeapPhase = iota
+
+	// The leap second has happened
+	LeapPassed
+)
+
+// Sent to the subscribers of a LeapNotifier around each leap second
+type LeapEvent struct {
+	Phase LeapPhase
+	Leap  *LeapMoment
+}
+
+// A LeapNotifier tells it's subscribers when a leap second is coming up
+// and again once it is over, so they can pause work that can't cope with
+// 23:59:60.
+//
+// The leap second table is rechecked at least daily, so leap seconds
+// added to it while the notifier is running are picked up.
+type LeapNotifier struct {
+	before time.Duration
+	after  time.Duration
+	clock  Clock
+
+	mu          sync.Mutex
+	funcs       []func(LeapEvent)
+	chans       []chan<- LeapEvent
+	approaching *LeapEvent
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// How often the table is rechecked when no leap second is coming up
+const leapRecheck = 24 * time.Hour
+
+// Create a LeapNotifier that sends LeapApproaching events before ahead
+// of each leap second and LeapPassed events after once it has ended,
+// going by clock.
+func NewLeapNotifier(clock Clock, before, after time.Duration) *LeapNotifier {
+	n := &LeapNotifier{
+		before: before,
+		after:  after,
+		clock:  clock,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	cursor := clock.Now()
+
+	if lm := NextLeapSecond(cursor.Add(-time.Second)); lm != nil {
+		if !cursor.Before(lm.Moment.Add(-before)) {
+			n.approaching = &LeapEvent{LeapApproaching, lm}
+		}
+	}
+
+	go n.run(cursor)
+
+	return n
+}
+
+// Call fn with each event, and straight away if a leap second is already
+// approaching. fn is called with the notifier locked, so it should not
+// block or call back into the notifier.
+func (n *LeapNotifier) OnLeap(fn func(LeapEvent)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.funcs = append(n.funcs, fn)
+
+	if n.approaching != nil {
+		fn(*n.approaching)
+	}
+}
+
+// Send each event on c. Events are dropped if c is not ready, so it
+// should be buffered. If a leap second is approaching that is sent
+// straight away.
+func (n *LeapNotifier) Notify(c chan<- LeapEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.chans = append(n.chans, c)
+
+	if n.approaching != nil {
+		select {
+		case c <- *n.approaching:
+		default:
+		}
+	}
+}
+
+// Stop sending events. Once Stop returns no more events are sent.
+func (n *LeapNotifier) Stop() {
+	n.once.Do(func() { close(n.stop) })
+	<-n.done
+}
+
+func (n *LeapNotifier) run(cursor *TAI64N) {
+	defer close(n.done)
+
+	for {
+		ev, at := n.nextEvent(cursor)
+
+		if ev == nil {
+			if !sleepUntil(n.clock, n.clock.Now().Add(leapRecheck), n.stop) {
+				return
+			}
+
+			continue
+		}
+
+		if !sleepUntil(n.clock, at, n.stop) {
+			return
+		}
+
+		n.send(*ev)
+		cursor = at
+	}
+}
+
+// Return the first event due after cursor and when it is due
+func (n *LeapNotifier) nextEvent(cursor *TAI64N) (*LeapEvent, *TAI64N) {
+	moments := currentLeaps().moments
+
+	for i := 1; i < len(moments); i++ {
+		lm := moments[i]
+
+		if approach := lm.Moment.Add(-n.before); approach.After(cursor) {
+			return &LeapEvent{LeapApproaching, lm}, approach
+		}
+
+		// The leap second lasts until 1s after it's moment.
+		if passed := lm.Moment.Add(time.Second + n.after); passed.After(cursor) {
+			return &LeapEvent{LeapPassed, lm}, passed
+		}
+	}
+
+	return nil, nil
+}
+
+func (n *LeapNotifier) send(ev LeapEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if ev.Phase == LeapApproaching {
+		n.approaching = &ev
+	} else {
+		n.approaching = nil
+	}
+
+	for _, fn := range n.funcs {
+		fn(ev)
+	}
+
+	for _, c := range n.chans {
+		select {
+		case c <- ev:
+		default:
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextLeapSecond(t *testing.T) {
+	lm := NextLeapSecond(FromTime(time.Date(2012, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	require.NotNil(t, lm)
+
+	assert.True(t, lm.LeapSecond.Threshold.Equal(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)))
+
+	last := AllLeapMoments[len(AllLeapMoments)-1]
+	assert.Nil(t, NextLeapSecond(last.Moment))
+}
+
+func receiveLeap(t *testing.T, c <-chan LeapEvent) LeapEvent {
+	select {
+	case ev := <-c:
+		return ev
+	case <-time.After(3 * time.Second):
+		t.Fatal("no leap event")
+		return LeapEvent{}
+	}
+}
+
+func TestLeapNotifier(t *testing.T) {
+	leap, clock := clockBeforeLeap()
+
+	n := NewLeapNotifier(clock, time.Second, time.Second)
+	defer n.Stop()
+
+	c := make(chan LeapEvent, 2)
+	n.Notify(c)
+
+	var called []LeapPhase
+
+	n.OnLeap(func(ev LeapEvent) {
+		called = append(called, ev.Phase)
+	})
+
+	waitForAlarm(t, clock)
+	assert.Empty(t, c)
+
+	clock.Advance(time.Second)
+
+	ev := receiveLeap(t, c)
+	assert.Equal(t, LeapApproaching, ev.Phase)
+	assert.True(t, ev.Leap.Moment.Equal(leap))
+
+	// Still within the leap second plus after, so nothing has passed yet.
+	waitForAlarm(t, clock)
+	clock.Advance(2 * time.Second)
+	assert.Empty(t, c)
+
+	waitForAlarm(t, clock)
+	clock.Advance(time.Second)
+
+	ev = receiveLeap(t, c)
+	assert.Equal(t, LeapPassed, ev.Phase)
+	assert.True(t, ev.Leap.Moment.Equal(leap))
+
+	n.Stop()
+
+	assert.Equal(t, []LeapPhase{LeapApproaching, LeapPassed}, called)
+}
+
+func TestLeapNotifierAlreadyApproaching(t *testing.T) {
+	leap, clock := clockBeforeLeap()
+
+	n := NewLeapNotifier(clock, time.Hour, 0)
+	defer n.Stop()
+
+	c := make(chan LeapEvent, 1)
+	n.Notify(c)
+
+	ev := receiveLeap(t, c)
+	assert.Equal(t, LeapApproaching, ev.Phase)
+	assert.True(t, ev.Leap.Moment.Equal(leap))
+}
@@ -1,6 +1,10 @@
 package tai64n
 
-import "time"
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+)
 
 // Represents the first moment after a leap second occurs.
 type LeapSecond struct {
@@ -44,42 +48,142 @@ type LeapMoment struct {
 	Moment     *TAI64N
 }
 
-var AllLeapMoments []*LeapMoment
+// Lookup tables built from AllLeapSeconds, so that it can be binary
+// searched by UTC or by TAI.
+type leapTable struct {
+	src     []*LeapSecond // the AllLeapSeconds it was built from
+	utc     []int64       // the UNIX time of each threshold
+	offset  []uint64      // the offset from each threshold on
+	tai     []uint64      // the TAI64 seconds of each leap moment
+	moments []*LeapMoment
+}
+
+// The leapTable of AllLeapSeconds, built when first needed.
+var leaps atomic.Pointer[leapTable]
+
+// The leap moments of AllLeapSeconds as it was when the package was
+// initialized. It is a snapshot that is never reassigned, so leap seconds
+// added to AllLeapSeconds later are not in it; NextLeapSecond finds them.
+var AllLeapMoments = currentLeaps().moments
+
+// Rebuild the lookup tables from AllLeapSeconds. Appending to or
+// replacing AllLeapSeconds is noticed without this, but an entry changed
+// in place is not until it is called. AllLeapSeconds must not be changed
+// while conversions may be running in other goroutines.
+func RebuildLeapTables() {
+	leaps.Store(buildLeapTable(AllLeapSeconds))
+}
 
-func init() {
-	for _, ls := range AllLeapSeconds {
-		moment := FromTime(ls.Threshold)
-		moment.Seconds--
+// Return the tables for AllLeapSeconds, rebuilding them if it has been
+// appended to or replaced since they were built.
+func currentLeaps() *leapTable {
+	lt := leaps.Load()
 
-		AllLeapMoments = append(AllLeapMoments, &LeapMoment{ls, moment})
+	if lt == nil || !sameLeapSeconds(lt.src, AllLeapSeconds) {
+		lt = buildLeapTable(AllLeapSeconds)
+		leaps.Store(lt)
 	}
+
+	return lt
+}
+
+func sameLeapSeconds(a, b []*LeapSecond) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+func buildLeapTable(all []*LeapSecond) *leapTable {
+	lt := &leapTable{
+		src:     all,
+		utc:     make([]int64, len(all)),
+		offset:  make([]uint64, len(all)),
+		tai:     make([]uint64, len(all)),
+		moments: make([]*LeapMoment, len(all)),
+	}
+
+	for i, ls := range all {
+		lt.utc[i] = ls.Threshold.Unix()
+		lt.offset[i] = uint64(ls.Offset)
+
+		// The leap second is the one before the threshold, with the
+		// offset from the threshold on.
+		moment := &TAI64N{
+			Seconds: uint64(lt.utc[i]) + TAI64OriginalBase + lt.offset[i] - 1,
+		}
+
+		lt.tai[i] = moment.Seconds
+		lt.moments[i] = &LeapMoment{ls, moment}
+	}
+
+	return lt
 }
 
 // Return the number of leap seconds that occur previous to the given
 // time.
 func LeapSecondsInvolved(t time.Time) uint64 {
-	// performance bias: typically times will be in the recent history,
-	// because, well, computers. So check from most recent leap second
-	// backwards.
-
-	for i := len(AllLeapSeconds) - 1; i >= 0; i-- {
-		ls := AllLeapSeconds[i]
-		if t.Unix() >= ls.Threshold.Unix() {
-			return uint64(ls.Offset)
-		}
+	var (
+		lt  = currentLeaps()
+		sec = t.Unix()
+	)
+
+	i := sort.Search(len(lt.utc), func(i int) bool {
+		return lt.utc[i] > sec
+	})
+
+	if i == 0 {
+		return 0
 	}
 
-	return 0
+	return lt.offset[i-1]
+}
+
+// Return the index of the latest leap moment at or before the given
+// moment, or -1 if it is before the table.
+func nearestLeapIndex(lt *leapTable, t *TAI64N) int {
+	// Leap moments are always whole seconds, so comparing the seconds is
+	// enough.
+	return sort.Search(len(lt.tai), func(i int) bool {
+		return lt.tai[i] > t.Seconds
+	}) - 1
 }
 
+// Return the latest leap moment at or before the given moment, or nil if
+// it is before the table.
 func nearestLeapMoment(t *TAI64N) *LeapMoment {
-	for i := len(AllLeapMoments) - 1; i >= 0; i-- {
-		lm := AllLeapMoments[i]
+	lt := currentLeaps()
 
-		if t.Equal(lm.Moment) || t.After(lm.Moment) {
-			return lm
-		}
+	if i := nearestLeapIndex(lt, t); i >= 0 {
+		return lt.moments[i]
 	}
 
 	return nil
 }
+
+// Return the number of leap seconds between UTC and the moment, found by
+// searching the table in the TAI domain, and the leap moment whose
+// inserted second the moment falls within, if it does.
+func utcOffset(t *TAI64N) (uint64, *LeapMoment) {
+	lt := currentLeaps()
+	i := nearestLeapIndex(lt, t)
+
+	switch {
+	case i < 0:
+		return 0, nil
+	case t.Seconds > lt.tai[i]:
+		return lt.offset[i], nil
+	case i == 0:
+		// The first entry is where the table starts rather than a leap
+		// second, and the offset before it is 0.
+		return 0, nil
+	default:
+		// Taking the offset from after the leap second shows it as a
+		// repeat of the second before it.
+		return lt.offset[i], lt.moments[i]
+	}
+}
+
+// Return the leap moment whose inserted second the moment falls within,
+// or nil if it isn't within one.
+func leapSecondAt(t *TAI64N) *LeapMoment {
+	_, lm := utcOffset(t)
+	return lm
+}
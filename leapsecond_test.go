@@ -0,0 +1,182 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeapSecondsInvolved(t *testing.T) {
+	assert.Equal(t, uint64(0), LeapSecondsInvolved(time.Date(1971, time.December, 31, 23, 59, 59, 0, time.UTC)))
+
+	for i, ls := range AllLeapSeconds {
+		assert.Equal(t, uint64(ls.Offset), LeapSecondsInvolved(ls.Threshold))
+
+		if i > 0 {
+			prev := AllLeapSeconds[i-1]
+			assert.Equal(t, uint64(prev.Offset), LeapSecondsInvolved(ls.Threshold.Add(-1)))
+		}
+	}
+}
+
+func TestNearestLeapMoment(t *testing.T) {
+	assert.Nil(t, nearestLeapMoment(AllLeapMoments[0].Moment.Add(-1)))
+
+	for i, lm := range AllLeapMoments {
+		assert.Equal(t, lm, nearestLeapMoment(lm.Moment))
+		assert.Equal(t, lm, nearestLeapMoment(lm.Moment.Add(999*time.Millisecond)))
+		assert.Nil(t, leapSecondAt(lm.Moment.Add(time.Second)))
+
+		// The first entry is where the table starts rather than a leap.
+		if i > 0 {
+			assert.Equal(t, AllLeapMoments[i-1], nearestLeapMoment(lm.Moment.Add(-1)))
+			assert.Equal(t, lm, leapSecondAt(lm.Moment.Add(999*time.Millisecond)))
+		} else {
+			assert.Nil(t, leapSecondAt(lm.Moment))
+		}
+	}
+}
+
+func TestAppendLeapSecond(t *testing.T) {
+	saved := AllLeapSeconds
+	snapshot := AllLeapMoments
+
+	defer func() {
+		AllLeapSeconds = saved
+	}()
+
+	next := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	AllLeapSeconds = append(append([]*LeapSecond(nil), saved...), &LeapSecond{next, 38})
+
+	assert.Equal(t, uint64(38), LeapSecondsInvolved(next))
+
+	lm := NextLeapSecond(FromTime(time.Date(2029, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, lm.LeapSecond.Threshold.Equal(next))
+
+	// AllLeapMoments is a snapshot of the table at start up.
+	assert.Equal(t, snapshot, AllLeapMoments)
+	assert.Len(t, AllLeapMoments, len(saved))
+}
+
+func TestRebuildLeapTables(t *testing.T) {
+	saved := AllLeapSeconds
+
+	defer func() {
+		AllLeapSeconds = saved
+		RebuildLeapTables()
+	}()
+
+	next := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	AllLeapSeconds = append(append([]*LeapSecond(nil), saved...), &LeapSecond{next, 38})
+	assert.Equal(t, uint64(38), LeapSecondsInvolved(next))
+
+	// A change in place is only seen once the tables are rebuilt.
+	AllLeapSeconds[len(AllLeapSeconds)-1] = &LeapSecond{next, 39}
+	assert.Equal(t, uint64(38), LeapSecondsInvolved(next))
+
+	RebuildLeapTables()
+	assert.Equal(t, uint64(39), LeapSecondsInvolved(next))
+}
+
+var (
+	benchOld    = time.Date(1975, time.March, 1, 0, 0, 0, 0, time.UTC)
+	benchRecent = time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func BenchmarkLeapSecondsInvolvedOld(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		LeapSecondsInvolved(benchOld)
+	}
+}
+
+func BenchmarkLeapSecondsInvolvedRecent(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		LeapSecondsInvolved(benchRecent)
+	}
+}
+
+func BenchmarkNearestLeapMomentOld(b *testing.B) {
+	t := FromTime(benchOld)
+
+	for i := 0; i < b.N; i++ {
+		nearestLeapMoment(t)
+	}
+}
+
+func BenchmarkNearestLeapMomentRecent(b *testing.B) {
+	t := FromTime(benchRecent)
+
+	for i := 0; i < b.N; i++ {
+		nearestLeapMoment(t)
+	}
+}
+
+func BenchmarkStringOld(b *testing.B) {
+	t := FromTime(benchOld)
+
+	for i := 0; i < b.N; i++ {
+		_ = t.String()
+	}
+}
+
+func BenchmarkStringRecent(b *testing.B) {
+	t := FromTime(benchRecent)
+
+	for i := 0; i < b.N; i++ {
+		_ = t.String()
+	}
+}
+
+func TestTimeAroundEveryLeapSecond(t *testing.T) {
+	for i, ls := range AllLeapSeconds {
+		for off := -40 * time.Second; off <= 40*time.Second; off += 250 * time.Millisecond {
+			utc := ls.Threshold.Add(off)
+
+			back, leap := FromTime(utc).UTC()
+
+			assert.True(t, back.Equal(utc), "%s: %s != %s", ls.Threshold, back, utc)
+			assert.False(t, leap)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		before := FromTime(ls.Threshold.Add(-time.Second))
+		after := FromTime(ls.Threshold)
+
+		// TAI has one more second between them than UTC does.
+		assert.Equal(t, before.Seconds+2, after.Seconds)
+
+		for _, ns := range []uint32{0, 1, 5e8, 1e9 - 1} {
+			inLeap := &TAI64N{Seconds: before.Seconds + 1, Nanoseconds: ns}
+
+			utc, leap := inLeap.UTC()
+
+			assert.True(t, leap)
+			assert.True(t, utc.Equal(ls.Threshold.Add(time.Duration(ns)-time.Second)))
+
+			h, m, s := inLeap.Clock()
+			assert.Equal(t, []int{23, 59, 60}, []int{h, m, s})
+
+			_, _, d := inLeap.Date()
+			_, _, d2 := ls.Threshold.Add(-time.Second).Date()
+			assert.Equal(t, d2, d)
+		}
+	}
+}
+
+func TestTimeBeforeTable(t *testing.T) {
+	utc := time.Date(1960, time.March, 1, 2, 3, 4, 5, time.UTC)
+	m := FromTime(utc)
+
+	assert.True(t, m.Time().Equal(utc))
+
+	y, mo, d := m.Date()
+	assert.Equal(t, 1960, y)
+	assert.Equal(t, time.March, mo)
+	assert.Equal(t, 1, d)
+}
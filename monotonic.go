@@ -0,0 +1,52 @@
+package tai64n
+
+import (
+	"sync"
+	"time"
+)
+
+// A Clock that never returns the same moment twice and never goes
+// backwards, even if it is called twice within a nanosecond or the
+// system clock is stepped.
+//
+// The wall clock is only read once, when the Monotonic is created.
+// After that moments are measured from it using Go's monotonic clock
+// reading, so a step of the wall clock, backward or forward, is not
+// reflected in the moments returned; they continue to count real elapsed
+// seconds, including across leap seconds. Create a new Monotonic to pick
+// up a corrected wall clock.
+//
+// When more than one moment is asked for within the resolution of the
+// monotonic clock, each is 1ns after the one before.
+type Monotonic struct {
+	mu    sync.Mutex
+	base  TAI64N
+	start time.Time
+	last  TAI64N
+}
+
+// Create a Monotonic starting at the current moment.
+func NewMonotonic() *Monotonic {
+	start := time.Now()
+
+	return &Monotonic{
+		base:  *FromTime(start),
+		start: start,
+	}
+}
+
+// Return a moment strictly after any previously returned.
+func (m *Monotonic) Now() *TAI64N {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.base.Add(time.Since(m.start))
+
+	if !now.After(&m.last) {
+		now = m.last.Add(1)
+	}
+
+	m.last = *now
+
+	return now
+}
@@ -0,0 +1,38 @@
+package tai64n
+
+import "errors"
+
+// Returned when MessagePack data isn't a TAI64N extension
+var ErrInvalidMsgpack = errors.New("tai64n: invalid MessagePack time")
+
+// The MessagePack extension type used for moments, 'T' for TAI. Types 0
+// to 127 are for applications to assign, so readers in other languages
+// must register the same type.
+const MsgpackExtType = 0x54
+
+// A 12 byte payload has no fixext format, so ext 8 is used.
+const msgpackExt8 = 0xc7
+
+// Encode as a MessagePack extension holding the 12 byte storage format
+func (tai TAI64N) MarshalMsgpack() ([]byte, error) {
+	buf := make([]byte, 15)
+
+	buf[0] = msgpackExt8
+	buf[1] = 12
+	buf[2] = MsgpackExtType
+
+	tai.WriteStorage(buf[3:])
+
+	return buf, nil
+}
+
+// Decode a MessagePack extension written by MarshalMsgpack
+func (tai *TAI64N) UnmarshalMsgpack(data []byte) error {
+	if len(data) != 15 || data[0] != msgpackExt8 || data[1] != 12 || data[2] != MsgpackExtType {
+		return ErrInvalidMsgpack
+	}
+
+	tai.ReadStorage(data[3:])
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package tai64n
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalMsgpack(t *testing.T) {
+	data, err := testMoment.Add(500 * time.Millisecond).MarshalMsgpack()
+	require.NoError(t, err)
+
+	assert.Equal(t, "c70c544000000053618ea31dcd6500", hex.EncodeToString(data))
+}
+
+func TestUnmarshalMsgpack(t *testing.T) {
+	var tai TAI64N
+
+	err := tai.UnmarshalMsgpack(unhex(t, "c70c544000000053618ea31dcd6500"))
+	require.NoError(t, err)
+
+	assert.True(t, tai.Equal(testMoment.Add(500*time.Millisecond)))
+
+	for _, data := range []string{
+		"",
+		"c70c544000000053618ea31dcd65",
+		"c70c554000000053618ea31dcd6500",
+		"c70b544000000053618ea31dcd6500",
+		"d7ff4000000053618ea3",
+	} {
+		assert.Equal(t, ErrInvalidMsgpack, tai.UnmarshalMsgpack(unhex(t, data)), data)
+	}
+}
@@ -0,0 +1,369 @@
+// Package multilog writes TAI64N stamped logs into a directory using the
+// same layout as daemontools' multilog, so the output can be read by
+// tai64nlocal and the rest of the daemontools tooling.
+//
+// The directory holds the file being written, named current, and the
+// rotated files, named @<label>.s. A rotated file named @<label>.u was
+// recovered from a current that was cut short by a crash and may end
+// with a truncated line.
+//
+// Logs are read back with a Reader, which binary searches the files for
+// the first line at or after a given moment.
+package multilog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vektra/tai64n"
+)
+
+const (
+	// The size, in bytes, at which current is rotated when none is given.
+	DefaultMaxSize = 99999
+
+	// The number of rotated files kept when none is given.
+	DefaultMaxFiles = 10
+
+	// The name of the file being written.
+	Current = "current"
+)
+
+// Writer stamps every line written to it with a TAI64N label and appends
+// it to the current file of a multilog directory, rotating as needed.
+// A Writer is safe for concurrent use, but lines written concurrently
+// in several pieces may interleave.
+type Writer struct {
+	dir      string
+	maxSize  int64
+	maxFiles int
+
+	mu      sync.Mutex
+	cur     *os.File
+	size    int64
+	midLine bool
+}
+
+// Open the multilog directory dir, creating it if needed. current is
+// rotated once it reaches maxSize bytes and at most maxFiles rotated
+// files are kept. Zero values select DefaultMaxSize and DefaultMaxFiles.
+//
+// Rotation is left unfinished if the process dies part way through it,
+// so Open first completes any such rotation.
+func Open(dir string, maxSize int64, maxFiles int) (*Writer, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+
+	if maxFiles <= 0 {
+		maxFiles = DefaultMaxFiles
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{
+		dir:      dir,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+
+	err = w.recover()
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.openCurrent()
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Write p to current, prefixing each line with the moment it was started
+// at. The returned count does not include the labels.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cur == nil {
+		return 0, os.ErrClosed
+	}
+
+	written := 0
+
+	for len(p) > 0 {
+		line := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line = p[:i+1]
+		}
+
+		if !w.midLine {
+			stamp := label(tai64n.Now()) + " "
+
+			// Files end on line boundaries, so only rotate between lines.
+			if w.size > 0 && w.size+int64(len(stamp)+len(line)) > w.maxSize {
+				err := w.rotate()
+				if err != nil {
+					return written, err
+				}
+			}
+
+			n, err := w.cur.WriteString(stamp)
+			w.size += int64(n)
+			if err != nil {
+				return written, err
+			}
+		}
+
+		n, err := w.cur.Write(line)
+		w.size += int64(n)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		w.midLine = line[len(line)-1] != '\n'
+		p = p[len(line):]
+	}
+
+	return written, nil
+}
+
+// Force current to be rotated, regardless of its size.
+func (w *Writer) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cur == nil {
+		return os.ErrClosed
+	}
+
+	return w.rotate()
+}
+
+// Flush current to disk and close it. current is left in place to be
+// appended to when the directory is next opened.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cur == nil {
+		return os.ErrClosed
+	}
+
+	err := w.cur.Sync()
+	if cerr := w.cur.Close(); err == nil {
+		err = cerr
+	}
+
+	w.cur = nil
+
+	return err
+}
+
+// Rotate the files the same way multilog does: current is flushed and
+// marked executable to record that it was completely written, then
+// renamed into place. A crash at any point leaves a directory Open can
+// finish the rotation of.
+func (w *Writer) rotate() error {
+	if w.midLine {
+		n, err := w.cur.Write([]byte{'\n'})
+		w.size += int64(n)
+		if err != nil {
+			return err
+		}
+
+		w.midLine = false
+	}
+
+	err := w.cur.Sync()
+	if err != nil {
+		return err
+	}
+
+	err = w.cur.Close()
+	w.cur = nil
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(w.path(Current), 0744)
+	if err != nil {
+		return err
+	}
+
+	err = w.finish(".s")
+	if err != nil {
+		return err
+	}
+
+	err = w.purge()
+	if err != nil {
+		return err
+	}
+
+	return w.openCurrent()
+}
+
+// Give current its final rotated name with the given suffix.
+func (w *Writer) finish(suffix string) error {
+	err := os.Rename(w.path(Current), w.path(label(tai64n.Now())+suffix))
+	if err != nil {
+		return err
+	}
+
+	return syncDir(w.dir)
+}
+
+// Clean up after a previous writer. An executable current was completely
+// written and only needs renaming. Otherwise it is appended to, unless it
+// ends part way through a line, in which case it is set aside as unsafe.
+func (w *Writer) recover() error {
+	fi, err := os.Stat(w.path(Current))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if fi.Mode()&0100 != 0 {
+		err = w.finish(".s")
+	} else if torn, terr := endsMidLine(w.path(Current), fi.Size()); terr != nil {
+		return terr
+	} else if torn {
+		err = w.finish(".u")
+	} else {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return w.purge()
+}
+
+func (w *Writer) openCurrent() error {
+	f, err := os.OpenFile(w.path(Current), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	w.cur = f
+	w.size = fi.Size()
+	w.midLine = false
+
+	return nil
+}
+
+// Remove the oldest rotated files until at most maxFiles remain.
+func (w *Writer) purge() error {
+	names, err := Rotated(w.dir)
+	if err != nil {
+		return err
+	}
+
+	for len(names) > w.maxFiles {
+		err = os.Remove(w.path(names[0]))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		names = names[1:]
+	}
+
+	return nil
+}
+
+func (w *Writer) path(name string) string {
+	return filepath.Join(w.dir, name)
+}
+
+// Return the names of the rotated files in dir, oldest first.
+func Rotated(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, ent := range entries {
+		if IsRotated(ent.Name()) {
+			names = append(names, ent.Name())
+		}
+	}
+
+	// Labels are fixed width hex, so they sort in time order.
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Indicate if name is that of a rotated file, @<label>.s or @<label>.u
+func IsRotated(name string) bool {
+	if len(name) != 27 {
+		return false
+	}
+
+	if !strings.HasSuffix(name, ".s") && !strings.HasSuffix(name, ".u") {
+		return false
+	}
+
+	return tai64n.ParseTAI64NLabel(name[:25]) != nil
+}
+
+// Render the label of a moment the way daemontools does. tai64nlocal
+// only accepts lowercase hex.
+func label(t *tai64n.TAI64N) string {
+	return strings.ToLower(t.Label())
+}
+
+func endsMidLine(path string, size int64) (bool, error) {
+	if size == 0 {
+		return false, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+
+	defer f.Close()
+
+	var last [1]byte
+
+	_, err = f.ReadAt(last[:], size-1)
+	if err != nil {
+		return false, err
+	}
+
+	return last[0] != '\n', nil
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	defer d.Close()
+
+	return d.Sync()
+}
@@ -0,0 +1,253 @@
+package multilog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektra/tai64n"
+)
+
+func readLines(t *testing.T, path string) []string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestWriteStampsLines(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := Open(dir, 0, 0)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("hello\nwor"))
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("ld\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, w.Close())
+
+	lines := readLines(t, filepath.Join(dir, Current))
+	require.Equal(t, 2, len(lines))
+
+	for i, msg := range []string{"hello", "world"} {
+		assert.Equal(t, " "+msg, lines[i][25:])
+		assert.NotNil(t, tai64n.ParseTAI64NLabel(lines[i][:25]))
+		assert.Equal(t, strings.ToLower(lines[i][:25]), lines[i][:25])
+	}
+}
+
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := Open(dir, 100, 2)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		_, err = w.Write([]byte("0123456789\n"))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	names, err := Rotated(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(names))
+
+	for _, name := range names {
+		assert.True(t, strings.HasSuffix(name, ".s"))
+
+		fi, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err)
+
+		assert.True(t, fi.Size() <= 100)
+		assert.NotZero(t, fi.Mode()&0100)
+	}
+}
+
+func TestOpenFinishesRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Current), []byte("done\n"), 0744))
+
+	w, err := Open(dir, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	names, err := Rotated(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(names))
+	assert.True(t, strings.HasSuffix(names[0], ".s"))
+}
+
+func TestOpenSetsAsideTornCurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Current), []byte("trunc"), 0644))
+
+	w, err := Open(dir, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	names, err := Rotated(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(names))
+	assert.True(t, strings.HasSuffix(names[0], ".u"))
+}
+
+func TestIsRotated(t *testing.T) {
+	assert.True(t, IsRotated("@4000000053618ea300000000.s"))
+	assert.True(t, IsRotated("@4000000053618ea300000000.u"))
+	assert.False(t, IsRotated("@4000000053618ea300000000.x"))
+	assert.False(t, IsRotated("current"))
+	assert.False(t, IsRotated(".s"))
+}
+
+func writeLog(t *testing.T, path string, base *tai64n.TAI64N, from, to int) {
+	var buf strings.Builder
+
+	for i := from; i < to; i++ {
+		buf.WriteString(base.Add(time.Duration(i)*time.Second).Label() + " line " + strconv.Itoa(i) + "\n")
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte(buf.String()), 0644))
+}
+
+func TestReaderSeek(t *testing.T) {
+	dir := t.TempDir()
+	base := tai64n.FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+	writeLog(t, filepath.Join(dir, base.Add(99*time.Second).Label()+".s"), base, 0, 100)
+	writeLog(t, filepath.Join(dir, base.Add(199*time.Second).Label()+".s"), base, 100, 200)
+	writeLog(t, filepath.Join(dir, Current), base, 200, 300)
+
+	r, err := OpenReader(dir)
+	require.NoError(t, err)
+
+	defer r.Close()
+
+	for _, i := range []int{0, 1, 50, 99, 100, 150, 199, 200, 250, 299} {
+		require.NoError(t, r.Seek(base.Add(time.Duration(i)*time.Second)))
+		require.True(t, r.Next())
+		assert.Equal(t, "line "+strconv.Itoa(i), string(r.Line().Text))
+	}
+
+	require.NoError(t, r.Seek(base.Add(300*time.Second)))
+	assert.False(t, r.Next())
+	assert.NoError(t, r.Err())
+}
+
+func TestReaderRange(t *testing.T) {
+	dir := t.TempDir()
+	base := tai64n.FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+	writeLog(t, filepath.Join(dir, base.Add(99*time.Second).Label()+".s"), base, 0, 100)
+	writeLog(t, filepath.Join(dir, Current), base, 100, 200)
+
+	r, err := OpenReader(dir)
+	require.NoError(t, err)
+
+	defer r.Close()
+
+	var got []string
+
+	err = r.Range(base.Add(95*time.Second), base.Add(105*time.Second), func(l *Line) bool {
+		got = append(got, string(l.Text))
+		return true
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 10, len(got))
+	assert.Equal(t, "line 95", got[0])
+	assert.Equal(t, "line 104", got[9])
+}
+
+func TestSearchSkipsPartialLines(t *testing.T) {
+	dir := t.TempDir()
+	base := tai64n.FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+	path := filepath.Join(dir, Current)
+
+	writeLog(t, path, base, 0, 1000)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	fi, err := f.Stat()
+	require.NoError(t, err)
+
+	off, err := search(f, fi.Size(), base.Add(500*time.Second))
+	require.NoError(t, err)
+
+	data, err := readLine(f, off, fi.Size())
+	require.NoError(t, err)
+
+	assert.Equal(t, "line 500", string(parseLine(data).Text))
+}
+
+func TestSearchSkipsUnlabelledLines(t *testing.T) {
+	base := tai64n.FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+	lines := []string{
+		base.Label() + " a\n",
+		"no label\n",
+		base.Add(2*time.Second).Label() + " b\n",
+		base.Add(4*time.Second).Label() + " c\n",
+		"@40000000",
+	}
+
+	data := []byte(strings.Join(lines, ""))
+	size := int64(len(data))
+
+	var starts []int64
+
+	for i := range lines {
+		starts = append(starts, int64(len(strings.Join(lines[:i], ""))))
+	}
+
+	for secs, want := range map[int]int64{
+		0: starts[0],
+		1: starts[1],
+		2: starts[1],
+		3: starts[3],
+		4: starts[3],
+		5: starts[4],
+	} {
+		off, err := search(bytes.NewReader(data), size, base.Add(time.Duration(secs)*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, want, off, "%ds", secs)
+	}
+}
+
+func TestReaderSeekTornRotation(t *testing.T) {
+	dir := t.TempDir()
+	base := tai64n.FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+	torn := base.Label() + " first\n" + base.Add(2*time.Second).Label() + " second\n@40000000"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Current), []byte(torn), 0644))
+
+	w, err := Open(dir, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	third := base.Add(10*time.Second).Label() + " third\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Current), []byte(third), 0644))
+
+	r, err := OpenReader(dir)
+	require.NoError(t, err)
+
+	defer r.Close()
+
+	require.NoError(t, r.Seek(base.Add(time.Second)))
+	require.True(t, r.Next())
+	assert.Equal(t, "second", string(r.Line().Text))
+}
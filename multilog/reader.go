@@ -0,0 +1,336 @@
+package multilog
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/vektra/tai64n"
+)
+
+// A single line read back from a log.
+type Line struct {
+	Moment *tai64n.TAI64N
+	Text   []byte
+}
+
+// Reader reads the stamped lines from a sequence of log files in order,
+// and can seek to a moment without scanning the files linearly.
+type Reader struct {
+	files []string
+
+	idx   int
+	f     *os.File
+	br    *bufio.Reader
+	from  *tai64n.TAI64N
+	line  *Line
+	err   error
+	ended bool
+}
+
+// Create a Reader over files, which must be given oldest first. Files
+// named as multilog rotates them are bounded by the moment in their
+// name, which is used to pick the file to search when seeking.
+func NewReader(files []string) *Reader {
+	return &Reader{files: files, idx: -1}
+}
+
+// Create a Reader over every file in the multilog directory dir, from
+// the oldest rotated file through to current.
+func OpenReader(dir string) (*Reader, error) {
+	names, err := Rotated(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, name := range names {
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	cur := filepath.Join(dir, Current)
+
+	_, err = os.Stat(cur)
+	if err == nil {
+		files = append(files, cur)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return NewReader(files), nil
+}
+
+// Position the reader so that Next returns the first line at or after t.
+func (r *Reader) Seek(t *tai64n.TAI64N) error {
+	// A rotated file holds no lines after the moment in its name, so
+	// the first one named at or after t holds the first line we want.
+	idx := sort.Search(len(r.files), func(i int) bool {
+		end := fileEnd(r.files[i])
+		return end == nil || !end.Before(t)
+	})
+
+	r.closeFile()
+	r.err = nil
+	r.ended = false
+	r.line = nil
+	r.from = t
+
+	if idx == len(r.files) {
+		r.idx = idx
+		r.ended = true
+		return nil
+	}
+
+	f, err := os.Open(r.files[idx])
+	if err != nil {
+		r.err = err
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		r.err = err
+		return err
+	}
+
+	off, err := search(f, fi.Size(), t)
+	if err != nil {
+		f.Close()
+		r.err = err
+		return err
+	}
+
+	r.idx = idx
+	r.f = f
+	r.br = bufio.NewReader(io.NewSectionReader(f, off, fi.Size()-off))
+
+	return nil
+}
+
+// Advance to the next line, returning false once there are no more
+// lines or an error occurs.
+func (r *Reader) Next() bool {
+	for !r.ended && r.err == nil {
+		if r.br == nil {
+			if !r.openNext() {
+				return false
+			}
+		}
+
+		data, err := r.br.ReadBytes('\n')
+		if len(data) == 0 {
+			if err != nil && err != io.EOF {
+				r.err = err
+				return false
+			}
+
+			r.closeFile()
+			continue
+		}
+
+		l := parseLine(data)
+		if l == nil {
+			continue
+		}
+
+		if r.from != nil && l.Moment.Before(r.from) {
+			continue
+		}
+
+		r.line = l
+		return true
+	}
+
+	return false
+}
+
+// Return the line read by the last call to Next.
+func (r *Reader) Line() *Line {
+	return r.line
+}
+
+// Return the error, if any, that stopped Next.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Call fn with each line at or after start and before end, stopping
+// early if fn returns false.
+func (r *Reader) Range(start, end *tai64n.TAI64N, fn func(*Line) bool) error {
+	err := r.Seek(start)
+	if err != nil {
+		return err
+	}
+
+	for r.Next() {
+		if !r.line.Moment.Before(end) {
+			break
+		}
+
+		if !fn(r.line) {
+			break
+		}
+	}
+
+	return r.Err()
+}
+
+// Close the file currently being read.
+func (r *Reader) Close() error {
+	return r.closeFile()
+}
+
+func (r *Reader) openNext() bool {
+	r.idx++
+
+	if r.idx >= len(r.files) {
+		r.ended = true
+		return false
+	}
+
+	f, err := os.Open(r.files[r.idx])
+	if err != nil {
+		r.err = err
+		return false
+	}
+
+	r.f = f
+	r.br = bufio.NewReader(f)
+
+	return true
+}
+
+func (r *Reader) closeFile() error {
+	var err error
+
+	if r.f != nil {
+		err = r.f.Close()
+	}
+
+	r.f = nil
+	r.br = nil
+
+	return err
+}
+
+// Return the moment a log file was rotated at, or nil if it has not been.
+func fileEnd(path string) *tai64n.TAI64N {
+	name := filepath.Base(path)
+
+	if !IsRotated(name) {
+		return nil
+	}
+
+	return tai64n.ParseTAI64NLabel(name[:25])
+}
+
+// Parse a line into its moment and text, returning nil if it has no label.
+func parseLine(data []byte) *Line {
+	if len(data) < 25 || data[0] != '@' {
+		return nil
+	}
+
+	moment := tai64n.ParseTAI64NLabel(string(data[:25]))
+	if moment == nil {
+		return nil
+	}
+
+	text := data[25:]
+
+	if len(text) > 0 && text[0] == ' ' {
+		text = text[1:]
+	}
+
+	if len(text) > 0 && text[len(text)-1] == '\n' {
+		text = text[:len(text)-1]
+	}
+
+	return &Line{Moment: moment, Text: text}
+}
+
+// Binary search the byte offsets of f for the start of the first line
+// stamped at or after t, returning size if there is none. Lines without
+// a label, such as a partial line being written, are skipped over rather
+// than compared, so the offset returned may be that of unlabelled lines
+// just before the line found, or trailing the file if there is none.
+func search(f io.ReaderAt, size int64, t *tai64n.TAI64N) (int64, error) {
+	// Every labelled line starting before lo is before t, and the first
+	// labelled line starting at or after hi is not.
+	lo, hi := int64(0), size
+
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		start, err := lineStart(f, mid, size)
+		if err != nil {
+			return 0, err
+		}
+
+		var (
+			data []byte
+			l    *Line
+		)
+
+		// Probe the first labelled line starting between mid and hi.
+		for start < hi {
+			data, err = readLine(f, start, size)
+			if err != nil {
+				return 0, err
+			}
+
+			if l = parseLine(data); l != nil {
+				break
+			}
+
+			start += int64(len(data))
+		}
+
+		if l == nil {
+			hi = mid
+			continue
+		}
+
+		if !l.Moment.Before(t) {
+			hi = start
+			continue
+		}
+
+		lo = start + int64(len(data))
+
+		if lo > hi {
+			hi = lo
+		}
+	}
+
+	return lo, nil
+}
+
+// Return the offset of the first line starting at or after off.
+func lineStart(f io.ReaderAt, off, size int64) (int64, error) {
+	if off == 0 {
+		return 0, nil
+	}
+
+	data, err := readLine(f, off-1, size)
+	if err != nil {
+		return 0, err
+	}
+
+	return off - 1 + int64(len(data)), nil
+}
+
+// Read the line starting at off, including its newline if it has one.
+func readLine(f io.ReaderAt, off, size int64) ([]byte, error) {
+	br := bufio.NewReader(io.NewSectionReader(f, off, size-off))
+
+	data, err := br.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return data, nil
+}
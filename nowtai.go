@@ -0,0 +1,37 @@
+package tai64n
+
+// Indicates where the moment returned by NowTAI was read from.
+type Source int
+
+const (
+	// Computed from the system UTC time and the leap second table.
+	SourceLeapTable Source = iota
+
+	// Read directly from the kernel's TAI clock.
+	SourceKernel
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceLeapTable:
+		return "leap table"
+	case SourceKernel:
+		return "kernel"
+	default:
+		return "unknown"
+	}
+}
+
+// Return the current moment, preferring the kernel's TAI clock when the
+// system provides one. On Linux that is CLOCK_TAI, which is only used
+// once the kernel's TAI offset has been set (typically by chrony or
+// ptp4l), as it is otherwise the same as UTC. When the kernel clock can't
+// be used this falls back to Now, and so depends on AllLeapSeconds being
+// up to date.
+func NowTAI() (*TAI64N, Source) {
+	if t, ok := kernelTAI(); ok {
+		return t, SourceKernel
+	}
+
+	return Now(), SourceLeapTable
+}
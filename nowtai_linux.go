@@ -0,0 +1,32 @@
+package tai64n
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// The clock id of CLOCK_TAI, from linux/time.h
+const clockTAI = 11
+
+func kernelTAI() (*TAI64N, bool) {
+	// Modes is left zero, so this only reads the kernel's state.
+	var tx syscall.Timex
+
+	_, err := syscall.Adjtimex(&tx)
+	if err != nil || tx.Tai == 0 {
+		return nil, false
+	}
+
+	var ts syscall.Timespec
+
+	_, _, errno := syscall.Syscall(syscall.SYS_CLOCK_GETTIME, clockTAI, uintptr(unsafe.Pointer(&ts)), 0)
+	if errno != 0 {
+		return nil, false
+	}
+
+	// CLOCK_TAI counts from 1970-01-01 TAI, the same as the TAI64 base.
+	return &TAI64N{
+		Seconds:     TAI64OriginalBase + uint64(ts.Sec),
+		Nanoseconds: uint32(ts.Nsec),
+	}, true
+}
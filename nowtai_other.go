@@ -0,0 +1,8 @@
+//go:build !linux
+// +build !linux
+
+package tai64n
+
+func kernelTAI() (*TAI64N, bool) {
+	return nil, false
+}
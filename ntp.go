@@ -0,0 +1,112 @@
+package tai64n
+
+import (
+	"sort"
+	"time"
+)
+
+// An NTP timestamp: 32 bits of seconds since 1900-01-01 00:00:00 UTC and
+// 32 bits of fraction. The seconds wrap every 136 years, each wrap being
+// a new era; era 0 started in 1900 and era 1 starts in 2036.
+//
+// NTP time is UTC based, so like UNIX time it does not count leap
+// seconds. An inserted leap second is shown by repeating 23:59:59.
+type NTPTimestamp uint64
+
+// The NTP short format: 16 bits of seconds and 16 bits of fraction. It is
+// used for durations such as root delay and dispersion.
+type NTPShort uint32
+
+// The leap indicator of an NTP packet, warning of a leap second at the
+// end of the current UTC day.
+type LeapIndicator uint8
+
+const (
+	LeapNone   LeapIndicator = 0 // no leap second today
+	LeapInsert LeapIndicator = 1 // the last minute of today has 61 seconds
+	LeapDelete LeapIndicator = 2 // the last minute of today has 59 seconds
+	LeapAlarm  LeapIndicator = 3 // the clock is unsynchronized
+)
+
+// The seconds from the NTP epoch to the UNIX epoch
+const ntpUnixOffset = 2208988800
+
+// Convert from an NTP timestamp. The era is chosen as in RFC 4330: when
+// the top bit of the seconds is clear the timestamp is taken to be in era
+// 1, covering moments from 1968 to 2104.
+func FromNTP(ts NTPTimestamp) *TAI64N {
+	if ts>>63 == 0 {
+		return FromNTPEra(1, ts)
+	}
+
+	return FromNTPEra(0, ts)
+}
+
+// Convert from an NTP timestamp in the given era. A repeated 23:59:59 is
+// taken to be the first of the two, as the leap second itself can't be
+// told apart from it.
+func FromNTPEra(era int, ts NTPTimestamp) *TAI64N {
+	var (
+		secs  = int64(era)<<32 + int64(ts>>32) - ntpUnixOffset
+		nsecs = (uint64(uint32(ts))*1e9 + 1<<31) >> 32
+	)
+
+	if nsecs >= 1e9 {
+		secs++
+		nsecs -= 1e9
+	}
+
+	return FromTime(time.Unix(secs, int64(nsecs)).UTC())
+}
+
+// Convert to an NTP timestamp and the era it is in. The leap second
+// itself is shown as a repeated 23:59:59, as NTP does.
+func (tai *TAI64N) NTP() (ts NTPTimestamp, era int) {
+	secs := tai.Time().Unix() + ntpUnixOffset
+	era = int(secs >> 32)
+
+	frac := uint64(tai.Nanoseconds) << 32 / 1e9
+
+	return NTPTimestamp(uint64(uint32(secs))<<32 | frac), era
+}
+
+// Return the leap indicator an NTP server would send at this moment,
+// which warns of a leap second from the start of the UTC day that ends
+// with one until the leap second itself has passed.
+func (tai *TAI64N) NTPLeapIndicator() LeapIndicator {
+	if leapSecondAt(tai) != nil {
+		return LeapInsert
+	}
+
+	utc := tai.Time()
+	year, month, day := utc.Date()
+	midnight := time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+
+	lt := currentLeaps()
+
+	i := sort.Search(len(lt.utc), func(i int) bool {
+		return lt.utc[i] >= midnight.Unix()
+	})
+
+	// The first entry of the table is where it starts rather than a leap.
+	if i == 0 || i == len(lt.utc) || lt.utc[i] != midnight.Unix() {
+		return LeapNone
+	}
+
+	if lt.offset[i] > lt.offset[i-1] {
+		return LeapInsert
+	}
+
+	return LeapDelete
+}
+
+// Convert from a duration, which is truncated to the short format's
+// resolution of about 15µs and must be less than 65536s.
+func NTPShortFromDuration(dur time.Duration) NTPShort {
+	return NTPShort(uint64(dur) << 16 / uint64(time.Second))
+}
+
+// Convert to a duration
+func (s NTPShort) Duration() time.Duration {
+	return time.Duration(uint64(s) * uint64(time.Second) >> 16)
+}
@@ -0,0 +1,80 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNTP(t *testing.T) {
+	m := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 5e8, time.UTC))
+
+	ts, era := m.NTP()
+
+	assert.Equal(t, 0, era)
+	assert.Equal(t, NTPTimestamp(uint64(1398902400+ntpUnixOffset)<<32|1<<31), ts)
+
+	assert.True(t, FromNTP(ts).Equal(m))
+	assert.True(t, FromNTPEra(0, ts).Equal(m))
+}
+
+func TestNTPKeepsNanoseconds(t *testing.T) {
+	m := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+	for _, ns := range []uint32{0, 1, 2, 3, 999999999} {
+		m.Nanoseconds = ns
+
+		ts, era := m.NTP()
+
+		assert.True(t, FromNTPEra(era, ts).Equal(m), "%d", ns)
+	}
+}
+
+func TestNTPEra(t *testing.T) {
+	// Era 1 starts at 2036-02-07 06:28:16 UTC
+	m := FromTime(time.Date(2036, time.February, 7, 6, 28, 16, 0, time.UTC))
+
+	ts, era := m.NTP()
+
+	assert.Equal(t, 1, era)
+	assert.Equal(t, NTPTimestamp(0), ts)
+	assert.True(t, FromNTP(ts).Equal(m))
+
+	m = FromTime(time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC))
+
+	ts, era = m.NTP()
+
+	assert.Equal(t, -1, era)
+	assert.True(t, FromNTPEra(era, ts).Equal(m))
+}
+
+func TestNTPAtLeap(t *testing.T) {
+	after := FromTime(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC))
+	leap := after.Add(-time.Second)
+
+	ts, _ := leap.NTP()
+	utc := time.Date(2012, time.June, 30, 23, 59, 59, 0, time.UTC)
+
+	assert.Equal(t, NTPTimestamp(uint64(utc.Unix()+ntpUnixOffset)<<32), ts)
+
+	ts, _ = after.NTP()
+
+	assert.True(t, FromNTP(ts).Equal(after))
+}
+
+func TestNTPLeapIndicator(t *testing.T) {
+	noon := FromTime(time.Date(2012, time.June, 30, 12, 0, 0, 0, time.UTC))
+	after := FromTime(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC))
+	other := FromTime(time.Date(2012, time.June, 29, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, LeapInsert, noon.NTPLeapIndicator())
+	assert.Equal(t, LeapInsert, after.Add(-time.Second).NTPLeapIndicator())
+	assert.Equal(t, LeapNone, after.NTPLeapIndicator())
+	assert.Equal(t, LeapNone, other.NTPLeapIndicator())
+}
+
+func TestNTPShort(t *testing.T) {
+	assert.Equal(t, NTPShort(0x00018000), NTPShortFromDuration(1500*time.Millisecond))
+	assert.Equal(t, 1500*time.Millisecond, NTPShort(0x00018000).Duration())
+}
@@ -0,0 +1,96 @@
+package tai64n
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Returned for a time that is not in the RFC3339 format accepted here
+var ErrInvalidRFC3339 = errors.New("tai64n: invalid RFC3339 time")
+
+// Returned for a label that is not in the canonical ascii format
+var ErrInvalidLabel = errors.New("tai64n: invalid TAI64N label")
+
+// Returned when encoding a moment whose year doesn't fit RFC3339
+var ErrYearRange = errors.New("tai64n: year outside of range [0,9999]")
+
+// Render as RFC3339, as String does, provided the year has 4 digits so
+// that ParseRFC3339 can read it back.
+func (tai *TAI64N) rfc3339() (string, error) {
+	if year, _, _ := tai.Date(); year < 0 || year > 9999 {
+		return "", ErrYearRange
+	}
+
+	return tai.String(), nil
+}
+
+// Parse a RFC3339 time, with an optional fraction of up to 9 digits and
+// any offset. Unlike time.Parse a second of 60 is accepted, provided the
+// leap second table has a leap second at that moment.
+func ParseRFC3339(s string) (TAI64N, error) {
+	// The second is the 2 digits after the 2nd colon of the clock.
+	leap := len(s) >= 19 && s[16] == ':' && s[17:19] == "60"
+
+	if leap {
+		s = s[:17] + "59" + s[19:]
+	}
+
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits := 0
+
+		for _, c := range s[i+1:] {
+			if c < '0' || c > '9' {
+				break
+			}
+
+			digits++
+		}
+
+		if digits > 9 {
+			return TAI64N{}, ErrInvalidRFC3339
+		}
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return TAI64N{}, ErrInvalidRFC3339
+	}
+
+	if !leap {
+		return *FromTime(t), nil
+	}
+
+	// The offset may move the leap second to another hour and day, so
+	// check it in UTC.
+	utc := t.UTC()
+
+	tai, err := FromCivil(Civil{
+		Year:       utc.Year(),
+		Month:      utc.Month(),
+		Day:        utc.Day(),
+		Hour:       utc.Hour(),
+		Minute:     utc.Minute(),
+		Second:     60,
+		Nanosecond: utc.Nanosecond(),
+	})
+	if err != nil {
+		return TAI64N{}, err
+	}
+
+	return *tai, nil
+}
+
+// Parse either the canonical ascii format or a RFC3339 time
+func parseText(s string) (TAI64N, error) {
+	if len(s) == 0 || s[0] != '@' {
+		return ParseRFC3339(s)
+	}
+
+	tai := ParseTAI64NLabel(s)
+	if tai == nil {
+		return TAI64N{}, ErrInvalidLabel
+	}
+
+	return *tai, nil
+}
@@ -0,0 +1,112 @@
+package tai64n
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRFC3339(t *testing.T) {
+	tm := time.Date(2014, time.May, 1, 13, 14, 15, 123456789, time.UTC)
+
+	tai, err := ParseRFC3339("2014-05-01T13:14:15.123456789Z")
+	require.NoError(t, err)
+
+	assert.True(t, tai.Equal(FromTime(tm)))
+
+	tai, err = ParseRFC3339("2014-05-01T15:14:15.5+02:00")
+	require.NoError(t, err)
+
+	assert.True(t, tai.Equal(FromTime(tm.Truncate(time.Second).Add(500*time.Millisecond))))
+}
+
+func TestParseRFC3339LeapSecond(t *testing.T) {
+	leap := AllLeapMoments[len(AllLeapMoments)-1].Moment
+
+	tai, err := ParseRFC3339("2016-12-31T23:59:60Z")
+	require.NoError(t, err)
+
+	assert.True(t, tai.Equal(leap))
+
+	tai, err = ParseRFC3339("2016-12-31T23:59:60.75Z")
+	require.NoError(t, err)
+
+	assert.True(t, tai.Equal(leap.Add(750*time.Millisecond)))
+
+	tai, err = ParseRFC3339("2017-01-01T00:59:60+01:00")
+	require.NoError(t, err)
+
+	assert.True(t, tai.Equal(leap))
+
+	for _, lm := range AllLeapMoments[1:] {
+		tai, err := ParseRFC3339(lm.Moment.String())
+		require.NoError(t, err)
+
+		assert.True(t, tai.Equal(lm.Moment), "%s", lm.Moment)
+	}
+}
+
+func TestParseRFC3339Rejects(t *testing.T) {
+	for _, s := range []string{
+		"2014-05-01T23:59:60Z",
+		"2016-12-31T22:59:60Z",
+		"2016-12-31T23:59:60+01:00",
+	} {
+		_, err := ParseRFC3339(s)
+		assert.Equal(t, ErrNoLeapSecond, err, s)
+	}
+
+	for _, s := range []string{
+		"",
+		"2014-05-01",
+		"2014-05-01T13:14:15",
+		"2014-05-01T13:14:15.1234567891Z",
+		"2014-05-01T13:14:61Z",
+	} {
+		_, err := ParseRFC3339(s)
+		assert.Equal(t, ErrInvalidRFC3339, err, s)
+	}
+}
+
+func TestJSONLeapSecond(t *testing.T) {
+	leap := AllLeapMoments[len(AllLeapMoments)-1].Moment.Add(time.Millisecond)
+
+	bytes, err := json.Marshal(cont{Time: leap})
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"Time":"2016-12-31T23:59:60.001Z"}`, string(bytes))
+
+	var c cont
+
+	err = json.Unmarshal(bytes, &c)
+	require.NoError(t, err)
+
+	assert.True(t, c.Time.Equal(leap))
+}
+
+func TestJSONYearRange(t *testing.T) {
+	for _, tai := range []TAI64N{
+		{},
+		*FromTime(time.Date(12000, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	} {
+		_, err := json.Marshal(tai)
+		assert.ErrorIs(t, err, ErrYearRange)
+	}
+
+	zero := FromTime(time.Time{})
+
+	bytes, err := json.Marshal(zero)
+	require.NoError(t, err)
+
+	assert.Equal(t, `"0001-01-01T00:00:00Z"`, string(bytes))
+
+	var tai TAI64N
+
+	err = json.Unmarshal(bytes, &tai)
+	require.NoError(t, err)
+
+	assert.True(t, tai.Equal(zero))
+}
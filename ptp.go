@@ -0,0 +1,57 @@
+package tai64n
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Returned when a moment can't be held in a PTP timestamp.
+var ErrPTPRange = errors.New("tai64n: moment out of PTP timestamp range")
+
+// A PTP (IEEE 1588) timestamp. PTP counts TAI seconds from 1970-01-01
+// 00:00:00 TAI, the same as TAI64N, so converting between the two never
+// consults the leap second table and is lossless.
+type PTPTimestamp struct {
+	Seconds     uint64 // only the low 48 bits are used
+	Nanoseconds uint32
+}
+
+// The size of a PTP timestamp on the wire
+const PTPTimestampSize = 10
+
+const maxPTPSeconds = 1<<48 - 1
+
+// Convert from a PTP timestamp
+func FromPTP(ts PTPTimestamp) *TAI64N {
+	return &TAI64N{
+		Seconds:     TAI64OriginalBase + ts.Seconds&maxPTPSeconds,
+		Nanoseconds: ts.Nanoseconds,
+	}
+}
+
+// Convert to a PTP timestamp, which can't hold moments before 1970 or
+// more than 2^48 seconds after it.
+func (tai *TAI64N) PTP() (PTPTimestamp, error) {
+	if tai.Seconds < TAI64OriginalBase || tai.Seconds-TAI64OriginalBase > maxPTPSeconds {
+		return PTPTimestamp{}, ErrPTPRange
+	}
+
+	return PTPTimestamp{
+		Seconds:     tai.Seconds - TAI64OriginalBase,
+		Nanoseconds: tai.Nanoseconds,
+	}, nil
+}
+
+// Return the value in it's 10 byte wire format: 48 bits of seconds and
+// 32 bits of nanoseconds, both big endian.
+func (ts *PTPTimestamp) WriteStorage(buf []byte) {
+	binary.BigEndian.PutUint16(buf[:], uint16(ts.Seconds>>32))
+	binary.BigEndian.PutUint32(buf[2:], uint32(ts.Seconds))
+	binary.BigEndian.PutUint32(buf[6:], ts.Nanoseconds)
+}
+
+// Update the value from it's 10 byte wire format
+func (ts *PTPTimestamp) ReadStorage(buf []byte) {
+	ts.Seconds = uint64(binary.BigEndian.Uint16(buf[:]))<<32 | uint64(binary.BigEndian.Uint32(buf[2:]))
+	ts.Nanoseconds = binary.BigEndian.Uint32(buf[6:])
+}
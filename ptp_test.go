@@ -0,0 +1,53 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPTP(t *testing.T) {
+	m := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 5, time.UTC))
+
+	ts, err := m.PTP()
+	require.NoError(t, err)
+
+	// PTP time is ahead of UTC by the 35 leap seconds of the time.
+	assert.Equal(t, uint64(1398902400+35), ts.Seconds)
+	assert.Equal(t, uint32(5), ts.Nanoseconds)
+
+	assert.True(t, FromPTP(ts).Equal(m))
+}
+
+func TestPTPAtLeap(t *testing.T) {
+	leap := FromTime(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)).Add(-time.Second)
+
+	ts, err := leap.PTP()
+	require.NoError(t, err)
+
+	assert.True(t, FromPTP(ts).Equal(leap))
+}
+
+func TestPTPRange(t *testing.T) {
+	_, err := (&TAI64N{Seconds: TAI64OriginalBase - 1}).PTP()
+	assert.Equal(t, ErrPTPRange, err)
+
+	_, err = (&TAI64N{Seconds: TAI64OriginalBase + 1<<48}).PTP()
+	assert.Equal(t, ErrPTPRange, err)
+}
+
+func TestPTPStorage(t *testing.T) {
+	ts := PTPTimestamp{Seconds: 0x123456789abc, Nanoseconds: 0x3b9ac9ff}
+
+	buf := make([]byte, PTPTimestampSize)
+	ts.WriteStorage(buf)
+
+	assert.Equal(t, []byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0x3b, 0x9a, 0xc9, 0xff}, buf)
+
+	var back PTPTimestamp
+	back.ReadStorage(buf)
+
+	assert.Equal(t, ts, back)
+}
@@ -0,0 +1,125 @@
+package tai64n
+
+import (
+	"math"
+	"time"
+)
+
+// Describes how a smeared clock absorbs a leap second. Rather than
+// repeating a second, a smeared clock runs slightly slow over a window
+// around the leap second so that it never jumps.
+type SmearPolicy struct {
+	// When the window starts, relative to the UTC midnight the leap
+	// second comes before, and how long it lasts in smeared time. The
+	// window must start before the leap second.
+	Start  time.Duration
+	Length time.Duration
+
+	// Return the fraction of the leap second absorbed once the fraction
+	// x of the window has passed. It must increase from 0 at 0 to 1 at 1.
+	Shape func(x float64) float64
+}
+
+var (
+	// The 24 hour linear smear from noon to noon UTC used by Google and
+	// AWS.
+	SmearLinear24h = &SmearPolicy{-12 * time.Hour, 24 * time.Hour, linearSmear}
+
+	// A 24 hour smear from noon to noon UTC that eases in and out along a
+	// cosine, so the clock rate changes smoothly.
+	SmearCosine24h = &SmearPolicy{-12 * time.Hour, 24 * time.Hour, cosineSmear}
+
+	// UTC-SLS, which smears linearly over the last 1000 seconds before
+	// the leap second.
+	SmearUTCSLS = &SmearPolicy{-1000 * time.Second, 1000 * time.Second, linearSmear}
+)
+
+func linearSmear(x float64) float64 {
+	return x
+}
+
+func cosineSmear(x float64) float64 {
+	return (1 - math.Cos(math.Pi*x)) / 2
+}
+
+// Convert from the time read from a clock smeared by policy
+func FromSmearedTime(t time.Time, policy *SmearPolicy) *TAI64N {
+	for i := 1; i < len(AllLeapSeconds); i++ {
+		start, tai, delta := policy.window(i)
+
+		if t.Before(start) || !t.Before(start.Add(policy.Length)) {
+			continue
+		}
+
+		elapsed := t.Sub(start)
+
+		return tai.Add(elapsed + policy.absorbed(elapsed, delta))
+	}
+
+	return FromTime(t)
+}
+
+// Convert to the time a clock smeared by policy would read at this moment
+func (tai *TAI64N) ToSmearedTime(policy *SmearPolicy) time.Time {
+	for i := 1; i < len(AllLeapSeconds); i++ {
+		start, startTAI, delta := policy.window(i)
+
+		elapsed := tai.taiSince(startTAI)
+
+		if elapsed < 0 || elapsed >= policy.Length+delta {
+			continue
+		}
+
+		// Find the first smeared time into the window that, plus the part
+		// of the leap second absorbed by then, reaches elapsed.
+		lo, hi := time.Duration(0), policy.Length
+
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+
+			if mid+policy.absorbed(mid, delta) < elapsed {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+
+		return start.Add(lo)
+	}
+
+	return tai.Time()
+}
+
+// Return the part of a leap second of length delta absorbed once elapsed
+// of the window has passed, rounded to the nearest nanosecond.
+func (p *SmearPolicy) absorbed(elapsed, delta time.Duration) time.Duration {
+	x := float64(elapsed) / float64(p.Length)
+
+	return time.Duration(math.Round(p.Shape(x) * float64(delta)))
+}
+
+// Return the start of the window around the i'th entry of the leap
+// second table, as both a UTC time and a moment, and the length of the
+// leap second, negative if it was removed.
+func (p *SmearPolicy) window(i int) (time.Time, *TAI64N, time.Duration) {
+	var (
+		prev  = AllLeapSeconds[i-1]
+		ls    = AllLeapSeconds[i]
+		start = ls.Threshold.Add(p.Start)
+	)
+
+	// Before the window starts the clock is unsmeared, with the offset
+	// from prior to the leap second.
+	tai := &TAI64N{
+		Seconds:     uint64(start.Unix()+int64(TAI64OriginalBase)) + uint64(prev.Offset),
+		Nanoseconds: uint32(start.Nanosecond()),
+	}
+
+	return start, tai, time.Duration(ls.Offset-prev.Offset) * time.Second
+}
+
+// Return the TAI seconds elapsed since other, without going through UTC.
+func (tai *TAI64N) taiSince(other *TAI64N) time.Duration {
+	return time.Duration(int64(tai.Seconds-other.Seconds))*time.Second +
+		time.Duration(int64(tai.Nanoseconds)-int64(other.Nanoseconds))
+}
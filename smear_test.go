@@ -0,0 +1,61 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var smearLeap = time.Date(2016, time.December, 31, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+
+func TestFromSmearedTimeLinear(t *testing.T) {
+	leap := FromTime(smearLeap).Add(-time.Second)
+
+	before := smearLeap.Add(-12 * time.Hour)
+	assert.True(t, FromSmearedTime(before, SmearLinear24h).Equal(FromTime(before)))
+
+	// Half way through the window, half the leap second is absorbed.
+	assert.True(t, FromSmearedTime(smearLeap, SmearLinear24h).Equal(leap.Add(500*time.Millisecond)))
+
+	after := smearLeap.Add(12 * time.Hour)
+	assert.True(t, FromSmearedTime(after, SmearLinear24h).Equal(FromTime(after)))
+
+	far := smearLeap.Add(-48 * time.Hour)
+	assert.True(t, FromSmearedTime(far, SmearLinear24h).Equal(FromTime(far)))
+}
+
+func TestFromSmearedTimeUTCSLS(t *testing.T) {
+	// UTC-SLS absorbs the whole leap second by midnight.
+	assert.True(t, FromSmearedTime(smearLeap, SmearUTCSLS).Equal(FromTime(smearLeap)))
+
+	mid := smearLeap.Add(-500 * time.Second)
+	assert.True(t, FromSmearedTime(mid, SmearUTCSLS).Equal(FromTime(mid).Add(500*time.Millisecond)))
+}
+
+func TestSmearRoundTrip(t *testing.T) {
+	for _, policy := range []*SmearPolicy{SmearLinear24h, SmearCosine24h, SmearUTCSLS} {
+		for _, off := range []time.Duration{-13 * time.Hour, -12 * time.Hour, -6 * time.Hour, -999 * time.Second, -time.Second, 0, 1, 6*time.Hour + 123456789, 12 * time.Hour} {
+			s := smearLeap.Add(off)
+			m := FromSmearedTime(s, policy)
+
+			back := m.ToSmearedTime(policy)
+
+			assert.True(t, back.Equal(s), "%s: %s != %s", off, back, s)
+		}
+	}
+}
+
+func TestSmearIsMonotonic(t *testing.T) {
+	var prev *TAI64N
+
+	for off := -13 * time.Hour; off < 13*time.Hour; off += 17 * time.Minute {
+		m := FromSmearedTime(smearLeap.Add(off), SmearCosine24h)
+
+		if prev != nil {
+			assert.True(t, m.After(prev))
+		}
+
+		prev = m
+	}
+}
@@ -11,24 +11,8 @@ import (
 // TAI time.
 const TAI64OriginalBase = uint64(4611686018427387904)
 
-var (
-	curLS       = time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC)
-	curLSOffset = uint64(36)
-)
-
 func nowBase(now time.Time) int64 {
-	sec := now.Unix()
-
-	// perf bias: most users set their server time to the current
-	// time on earth, so we bias this to check that we're in that
-	// time region before checking the complete leap second table.
-
-	switch {
-	case sec >= curLS.Unix():
-		return int64(TAI64OriginalBase + curLSOffset)
-	default:
-		return int64(TAI64OriginalBase + LeapSecondsInvolved(now))
-	}
+	return int64(TAI64OriginalBase + LeapSecondsInvolved(now))
 }
 
 // Indicates via Before, After, or Equal how to moments compare to eachother.
@@ -58,13 +42,27 @@ func FromTime(t time.Time) *TAI64N {
 	}
 }
 
-// Convert back to a time.Time
+// Convert back to a time.Time. A moment within a leap second is shown as
+// a repeat of the second before it; use UTC to tell them apart.
 func (tai *TAI64N) Time() time.Time {
-	t := time.Unix(int64(tai.Seconds-TAI64OriginalBase), int64(tai.Nanoseconds)).UTC()
+	t, _ := tai.UTC()
+	return t
+}
+
+// Convert back to a time.Time, also indicating if the moment falls within
+// an inserted leap second. time.Time can't represent 23:59:60, so such a
+// moment is returned as a repeat of 23:59:59 with leap set.
+func (tai *TAI64N) UTC() (t time.Time, leap bool) {
+	offset, lm := utcOffset(tai)
 
-	return t.Add(-time.Duration(LeapSecondsInvolved(t)) * time.Second)
+	t = time.Unix(int64(tai.Seconds-TAI64OriginalBase-offset), int64(tai.Nanoseconds)).UTC()
+
+	return t, lm != nil
 }
 
+// The size of the canonical binary format
+const StorageSize = 12
+
 // Return the value in it's canonical binary format
 func (tai *TAI64N) WriteStorage(buf []byte) {
 	binary.BigEndian.PutUint64(buf[:], tai.Seconds)
@@ -109,17 +107,37 @@ func ParseTAI64NLabel(label string) *TAI64N {
 	return ts
 }
 
+// Marshal as a RFC3339 string, with a leap second as 23:59:60. Like
+// time.Time, years outside of [0,9999] are an error.
 func (tai TAI64N) MarshalJSON() ([]byte, error) {
-	return tai.Time().MarshalJSON()
+	s, err := tai.rfc3339()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(`"` + s + `"`), nil
 }
 
+// Unmarshal a RFC3339 string, accepting leap seconds as ParseRFC3339 does
 func (tai *TAI64N) UnmarshalJSON(data []byte) (err error) {
-	var t time.Time
-	err = t.UnmarshalJSON(data)
+	s := string(data)
+
+	if s == "null" {
+		return nil
+	}
+
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return ErrInvalidRFC3339
+	}
+
+	t, err := ParseRFC3339(s[1 : len(s)-1])
+	if err != nil {
+		return err
+	}
 
-	*tai = *FromTime(t)
+	*tai = t
 
-	return err
+	return nil
 }
 
 // Indicated if the called moment is before the argument
@@ -1,6 +1,7 @@
 package tai64n
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"testing"
 	"time"
@@ -9,9 +10,25 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// A moment shared by the encoding tests
+var testMoment = FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return b
+}
+
 func TestNowBase(t *testing.T) {
 	var n time.Time
 
+	n = time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, int64(TAI64OriginalBase+37), nowBase(n))
+
+	n = time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, int64(TAI64OriginalBase+37), nowBase(n))
+
 	n = time.Date(2016, time.May, 1, 0, 0, 0, 0, time.UTC)
 	assert.Equal(t, int64(TAI64OriginalBase+36), nowBase(n))
 
@@ -26,8 +43,21 @@ func TestNow(t *testing.T) {
 	t1 := Now()
 	t2 := Now()
 
-	assert.True(t, t1.Seconds <= t2.Seconds)
-	assert.True(t, t1.Nanoseconds < t2.Nanoseconds)
+	assert.False(t, t2.Before(t1))
+}
+
+func TestMonotonic(t *testing.T) {
+	m := NewMonotonic()
+
+	assert.True(t, m.Now().Sub(Now()) < time.Second)
+
+	last := m.Now()
+
+	for i := 0; i < 1000; i++ {
+		n := m.Now()
+		require.True(t, n.After(last))
+		last = n
+	}
 }
 
 func TestFromTime(t *testing.T) {
@@ -87,8 +117,10 @@ func TestJSON(t *testing.T) {
 }
 
 func TestCompare(t *testing.T) {
-	m1 := Now()
-	m2 := Now()
+	clock := NewMonotonic()
+
+	m1 := clock.Now()
+	m2 := clock.Now()
 
 	assert.True(t, m1.Before(m2))
 	assert.Equal(t, m1.Compare(m2), Before)
@@ -143,15 +175,22 @@ func TestDate(t *testing.T) {
 }
 
 func TestDateAtLeap(t *testing.T) {
-	s := time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)
-	n := FromTime(s)
-	n.Seconds--
+	clock := NewFakeClock(FromTime(time.Date(2012, time.June, 30, 23, 59, 59, 0, time.UTC)))
+	clock.Advance(time.Second)
 
-	y1, m1, d1 := n.Date()
+	y1, m1, d1 := clock.Now().Date()
 
 	assert.Equal(t, y1, 2012)
 	assert.Equal(t, m1, time.June)
 	assert.Equal(t, d1, 30)
+
+	clock.Advance(time.Second)
+
+	y1, m1, d1 = clock.Now().Date()
+
+	assert.Equal(t, y1, 2012)
+	assert.Equal(t, m1, time.July)
+	assert.Equal(t, d1, 1)
 }
 
 func TestClock(t *testing.T) {
@@ -167,15 +206,22 @@ func TestClock(t *testing.T) {
 }
 
 func TestClockAtLeap(t *testing.T) {
-	s := time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)
-	n := FromTime(s)
-	n.Seconds--
+	clock := NewFakeClock(FromTime(time.Date(2012, time.June, 30, 23, 59, 59, 0, time.UTC)))
+	clock.Advance(time.Second)
 
-	h1, m1, s1 := n.Clock()
+	h1, m1, s1 := clock.Now().Clock()
 
 	assert.Equal(t, h1, 23)
 	assert.Equal(t, m1, 59)
 	assert.Equal(t, s1, 60)
+
+	clock.Advance(time.Second)
+
+	h1, m1, s1 = clock.Now().Clock()
+
+	assert.Equal(t, h1, 0)
+	assert.Equal(t, m1, 0)
+	assert.Equal(t, s1, 0)
 }
 
 func TestString(t *testing.T) {
@@ -183,3 +229,39 @@ func TestString(t *testing.T) {
 
 	assert.Equal(t, n.String(), n.Time().Format(time.RFC3339Nano))
 }
+
+func TestFakeClock(t *testing.T) {
+	start := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+	clock := NewFakeClock(start)
+
+	assert.True(t, clock.Now().Equal(start))
+
+	clock.Advance(1500 * time.Millisecond)
+
+	assert.Equal(t, start.Seconds+1, clock.Now().Seconds)
+	assert.Equal(t, uint32(5e8), clock.Now().Nanoseconds)
+
+	clock.Set(start)
+
+	assert.True(t, clock.Now().Equal(start))
+}
+
+func TestOffsetClock(t *testing.T) {
+	start := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC))
+	clock := NewOffsetClock(NewFakeClock(start), -time.Second)
+
+	assert.Equal(t, start.Seconds-1, clock.Now().Seconds)
+}
+
+func TestNowTAI(t *testing.T) {
+	n, src := NowTAI()
+
+	// Either source should agree with the leap table to well within a
+	// second on a synchronised host.
+	diff := n.Sub(Now())
+	if diff < 0 {
+		diff = -diff
+	}
+
+	assert.True(t, diff < time.Second, "%s clock differs by %s", src, diff)
+}
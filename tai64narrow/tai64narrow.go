@@ -0,0 +1,217 @@
+// Package tai64narrow converts between slices of moments and Apache Arrow
+// columns, either holding the moments themselves or UTC timestamps.
+package tai64narrow
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/vektra/tai64n"
+)
+
+var (
+	// Returned for a column whose type can't hold moments
+	ErrType = errors.New("tai64narrow: column is not of a moment type")
+
+	// Returned for a column with nulls, which have no moment
+	ErrNull = errors.New("tai64narrow: column has nulls")
+
+	// Returned for a moment outside the range of a nanosecond timestamp
+	ErrRange = errors.New("tai64narrow: moment out of timestamp range")
+)
+
+var (
+	// A column of moments in their 12 byte storage format, which sorts
+	// bytewise in time order.
+	FixedSizeBinaryType = &arrow.FixedSizeBinaryType{ByteWidth: 12}
+
+	// A column of moments as the TAI64 seconds and nanoseconds fields
+	StructType = arrow.StructOf(
+		arrow.Field{Name: "seconds", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "nanos", Type: arrow.PrimitiveTypes.Uint32},
+	)
+
+	// A column of UTC times, which can't represent leap seconds by itself
+	TimestampType = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+)
+
+// Build a FixedSizeBinaryType column of the moments
+func NewFixedSizeBinary(mem memory.Allocator, moments []tai64n.TAI64N) *array.FixedSizeBinary {
+	b := array.NewFixedSizeBinaryBuilder(mem, FixedSizeBinaryType)
+	defer b.Release()
+
+	b.Reserve(len(moments))
+
+	var buf [12]byte
+
+	for i := range moments {
+		moments[i].WriteStorage(buf[:])
+		b.Append(buf[:])
+	}
+
+	return b.NewFixedSizeBinaryArray()
+}
+
+// Read the moments of a FixedSizeBinaryType column
+func FromFixedSizeBinary(arr *array.FixedSizeBinary) ([]tai64n.TAI64N, error) {
+	if dt, ok := arr.DataType().(*arrow.FixedSizeBinaryType); !ok || dt.ByteWidth != 12 {
+		return nil, ErrType
+	}
+
+	if arr.NullN() != 0 {
+		return nil, ErrNull
+	}
+
+	moments := make([]tai64n.TAI64N, arr.Len())
+
+	for i := range moments {
+		moments[i].ReadStorage(arr.Value(i))
+	}
+
+	return moments, nil
+}
+
+// Build a StructType column of the moments
+func NewStruct(mem memory.Allocator, moments []tai64n.TAI64N) *array.Struct {
+	b := array.NewStructBuilder(mem, StructType)
+	defer b.Release()
+
+	b.Reserve(len(moments))
+
+	var (
+		secs  = b.FieldBuilder(0).(*array.Uint64Builder)
+		nanos = b.FieldBuilder(1).(*array.Uint32Builder)
+	)
+
+	for _, m := range moments {
+		b.Append(true)
+		secs.Append(m.Seconds)
+		nanos.Append(m.Nanoseconds)
+	}
+
+	return b.NewStructArray()
+}
+
+// Read the moments of a StructType column
+func FromStruct(arr *array.Struct) ([]tai64n.TAI64N, error) {
+	if !arrow.TypeEqual(arr.DataType(), StructType) {
+		return nil, ErrType
+	}
+
+	if arr.NullN() != 0 {
+		return nil, ErrNull
+	}
+
+	var (
+		secs  = arr.Field(0).(*array.Uint64)
+		nanos = arr.Field(1).(*array.Uint32)
+	)
+
+	if secs.NullN() != 0 || nanos.NullN() != 0 {
+		return nil, ErrNull
+	}
+
+	moments := make([]tai64n.TAI64N, arr.Len())
+
+	for i := range moments {
+		moments[i] = tai64n.TAI64N{
+			Seconds:     secs.Value(i),
+			Nanoseconds: nanos.Value(i),
+		}
+	}
+
+	return moments, nil
+}
+
+var (
+	minTimestamp = time.Unix(0, math.MinInt64)
+	maxTimestamp = time.Unix(0, math.MaxInt64)
+)
+
+// Build a TimestampType column of the moments. A moment within a leap
+// second is stored as a repeat of the second before it, as
+// tai64n.TAI64N.Time does, and is flagged in the returned leap column so
+// FromTimestamp can tell them apart.
+func NewTimestamp(mem memory.Allocator, moments []tai64n.TAI64N) (*array.Timestamp, *array.Boolean, error) {
+	tb := array.NewTimestampBuilder(mem, TimestampType)
+	defer tb.Release()
+
+	lb := array.NewBooleanBuilder(mem)
+	defer lb.Release()
+
+	tb.Reserve(len(moments))
+	lb.Reserve(len(moments))
+
+	for i := range moments {
+		t, leap := moments[i].UTC()
+
+		if t.Before(minTimestamp) || t.After(maxTimestamp) {
+			return nil, nil, ErrRange
+		}
+
+		tb.Append(arrow.Timestamp(t.UnixNano()))
+		lb.Append(leap)
+	}
+
+	return tb.NewTimestampArray(), lb.NewBooleanArray(), nil
+}
+
+// Read the moments of a UTC timestamp column of any unit. Where leap is
+// set the timestamp is taken to be the repeat of the second before a leap
+// second, as written by NewTimestamp. leap may be nil, in which case a
+// repeated second is read as the first of the two.
+func FromTimestamp(arr *array.Timestamp, leap *array.Boolean) ([]tai64n.TAI64N, error) {
+	dt, ok := arr.DataType().(*arrow.TimestampType)
+	if !ok || (dt.TimeZone != "UTC" && dt.TimeZone != "") {
+		return nil, ErrType
+	}
+
+	if arr.NullN() != 0 || (leap != nil && leap.NullN() != 0) {
+		return nil, ErrNull
+	}
+
+	if leap != nil && leap.Len() != arr.Len() {
+		return nil, ErrType
+	}
+
+	var (
+		unit    = timeUnit(dt.Unit)
+		perSec  = int64(time.Second / unit)
+		moments = make([]tai64n.TAI64N, arr.Len())
+	)
+
+	for i := range moments {
+		v := int64(arr.Value(i))
+
+		m := tai64n.FromTime(time.Unix(v/perSec, v%perSec*int64(unit)))
+
+		if leap != nil && leap.Value(i) {
+			m = m.Add(time.Second)
+
+			if !m.Civil().IsLeap {
+				return nil, tai64n.ErrNoLeapSecond
+			}
+		}
+
+		moments[i] = *m
+	}
+
+	return moments, nil
+}
+
+func timeUnit(u arrow.TimeUnit) time.Duration {
+	switch u {
+	case arrow.Second:
+		return time.Second
+	case arrow.Millisecond:
+		return time.Millisecond
+	case arrow.Microsecond:
+		return time.Microsecond
+	}
+
+	return time.Nanosecond
+}
@@ -0,0 +1,157 @@
+package tai64narrow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektra/tai64n"
+)
+
+// Moments either side of and within the 2016 leap second
+func testMoments() []tai64n.TAI64N {
+	start := tai64n.FromTime(time.Date(2016, time.December, 31, 23, 59, 59, 250, time.UTC))
+
+	var moments []tai64n.TAI64N
+
+	for i := 0; i < 4; i++ {
+		moments = append(moments, *start.Add(time.Duration(i) * 500 * time.Millisecond))
+	}
+
+	return moments
+}
+
+func TestFixedSizeBinary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	moments := testMoments()
+
+	arr := NewFixedSizeBinary(mem, moments)
+	defer arr.Release()
+
+	assert.True(t, arrow.TypeEqual(FixedSizeBinaryType, arr.DataType()))
+
+	var buf [12]byte
+
+	moments[0].WriteStorage(buf[:])
+	assert.Equal(t, buf[:], arr.Value(0))
+
+	got, err := FromFixedSizeBinary(arr)
+	require.NoError(t, err)
+
+	assert.Equal(t, moments, got)
+}
+
+func TestStruct(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	moments := testMoments()
+
+	arr := NewStruct(mem, moments)
+	defer arr.Release()
+
+	assert.True(t, arrow.TypeEqual(StructType, arr.DataType()))
+	assert.Equal(t, moments[1].Seconds, arr.Field(0).(*array.Uint64).Value(1))
+	assert.Equal(t, moments[1].Nanoseconds, arr.Field(1).(*array.Uint32).Value(1))
+
+	got, err := FromStruct(arr)
+	require.NoError(t, err)
+
+	assert.Equal(t, moments, got)
+}
+
+func TestTimestamp(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	moments := testMoments()
+
+	ts, leap, err := NewTimestamp(mem, moments)
+	require.NoError(t, err)
+
+	defer ts.Release()
+	defer leap.Release()
+
+	// The leap second repeats 23:59:59 and is flagged.
+	second := time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC).UnixNano()
+
+	assert.Equal(t, arrow.Timestamp(second+250), ts.Value(0))
+	assert.Equal(t, arrow.Timestamp(second+250+5e8), ts.Value(1))
+	assert.Equal(t, arrow.Timestamp(second+250), ts.Value(2))
+	assert.Equal(t, arrow.Timestamp(second+250+5e8), ts.Value(3))
+
+	for i, want := range []bool{false, false, true, true} {
+		assert.Equal(t, want, leap.Value(i))
+	}
+
+	got, err := FromTimestamp(ts, leap)
+	require.NoError(t, err)
+
+	assert.Equal(t, moments, got)
+
+	// Without the flags the repeated second is read as the first.
+	got, err = FromTimestamp(ts, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []tai64n.TAI64N{moments[0], moments[1], moments[0], moments[1]}, got)
+}
+
+func TestFromTimestampUnits(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	tm := time.Date(1969, time.December, 31, 23, 59, 58, 5e8, time.UTC)
+
+	b := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Millisecond})
+	defer b.Release()
+
+	b.Append(arrow.Timestamp(tm.UnixNano() / 1e6))
+
+	ts := b.NewTimestampArray()
+	defer ts.Release()
+
+	got, err := FromTimestamp(ts, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []tai64n.TAI64N{*tai64n.FromTime(tm)}, got)
+}
+
+func TestFromTimestampRejects(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	tb := array.NewTimestampBuilder(mem, TimestampType)
+	defer tb.Release()
+
+	lb := array.NewBooleanBuilder(mem)
+	defer lb.Release()
+
+	tb.Append(arrow.Timestamp(time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC).UnixNano()))
+	lb.Append(true)
+
+	ts := tb.NewTimestampArray()
+	defer ts.Release()
+
+	leap := lb.NewBooleanArray()
+	defer leap.Release()
+
+	_, err := FromTimestamp(ts, leap)
+	assert.Equal(t, tai64n.ErrNoLeapSecond, err)
+
+	tb.AppendNull()
+
+	nulls := tb.NewTimestampArray()
+	defer nulls.Release()
+
+	_, err = FromTimestamp(nulls, nil)
+	assert.Equal(t, ErrNull, err)
+
+	_, _, err = NewTimestamp(mem, []tai64n.TAI64N{{Seconds: tai64n.TAI64OriginalBase + 1<<40}})
+	assert.Equal(t, ErrRange, err)
+}
@@ -0,0 +1,142 @@
+// Package tai64nparquet writes and reads columns of moments in Parquet
+// files.
+//
+// A column of moments is a required FIXED_LEN_BYTE_ARRAY of length 12
+// holding the storage format, the big endian TAI64 seconds followed by
+// the nanoseconds, so it sorts bytewise in time order and keeps leap
+// seconds. Parquet has no logical type for TAI, so instead the column is
+// annotated in the file's key value metadata: the MetadataKey entry
+// lists the names of the columns holding moments, separated by commas.
+// Readers that don't know of the annotation see plain 12 byte values.
+package tai64nparquet
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/vektra/tai64n"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// The key of the file metadata entry annotating the columns of moments
+const MetadataKey = "tai64n.columns"
+
+var (
+	// Returned for a column that isn't annotated as holding moments
+	ErrNotMoments = errors.New("tai64nparquet: column does not hold moments")
+
+	// Returned for a column name that can't be used in a schema
+	ErrColumnName = errors.New("tai64nparquet: invalid column name")
+)
+
+// The row written, whose field is named by the schema's inname.
+type row struct {
+	Moment string
+}
+
+// Write the moments to pf as a Parquet file with a single annotated
+// column. pf is not closed.
+func Write(pf source.ParquetFile, column string, moments []tai64n.TAI64N) error {
+	if column == "" || strings.ContainsAny(column, `,=."\`) {
+		return ErrColumnName
+	}
+
+	schema := `{"Tag": "name=parquet_go_root, repetitiontype=REQUIRED", "Fields": [` +
+		`{"Tag": "name=` + column + `, inname=Moment, type=FIXED_LEN_BYTE_ARRAY, length=12, repetitiontype=REQUIRED"}]}`
+
+	pw, err := writer.NewParquetWriter(pf, schema, 1)
+	if err != nil {
+		return err
+	}
+
+	var buf [12]byte
+
+	for i := range moments {
+		moments[i].WriteStorage(buf[:])
+
+		if err := pw.Write(row{string(buf[:])}); err != nil {
+			// Stop the writer so it's workers don't leak; the write error
+			// is the one worth returning.
+			pw.WriteStop()
+			return err
+		}
+	}
+
+	kv := parquet.NewKeyValue()
+	kv.Key, kv.Value = MetadataKey, &column
+
+	pw.Footer.KeyValueMetadata = append(pw.Footer.KeyValueMetadata, kv)
+
+	return pw.WriteStop()
+}
+
+// Read the moments of the named column of a Parquet file, which must be
+// annotated as holding them. pf is not closed.
+func Read(pf source.ParquetFile, column string) ([]tai64n.TAI64N, error) {
+	pr, err := reader.NewParquetColumnReader(pf, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	defer pr.ReadStop()
+
+	if !annotated(pr.Footer, column) {
+		return nil, ErrNotMoments
+	}
+
+	path := common.PathToStr([]string{pr.SchemaHandler.GetRootExName(), column})
+
+	values, _, _, err := pr.ReadColumnByPath(path, pr.GetNumRows())
+	if err != nil {
+		return nil, err
+	}
+
+	moments := make([]tai64n.TAI64N, len(values))
+
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok || len(s) != 12 {
+			return nil, ErrNotMoments
+		}
+
+		moments[i].ReadStorage([]byte(s))
+	}
+
+	return moments, nil
+}
+
+// Indicate if the column is listed in the annotation and has the type
+// the annotation requires.
+func annotated(footer *parquet.FileMetaData, column string) bool {
+	listed := false
+
+	for _, kv := range footer.GetKeyValueMetadata() {
+		if kv.Key != MetadataKey || kv.Value == nil {
+			continue
+		}
+
+		for _, name := range strings.Split(*kv.Value, ",") {
+			if name == column {
+				listed = true
+			}
+		}
+	}
+
+	if !listed {
+		return false
+	}
+
+	for _, el := range footer.GetSchema() {
+		if el.GetName() == column {
+			return el.GetType() == parquet.Type_FIXED_LEN_BYTE_ARRAY &&
+				el.GetTypeLength() == 12 &&
+				el.GetRepetitionType() == parquet.FieldRepetitionType_REQUIRED
+		}
+	}
+
+	return false
+}
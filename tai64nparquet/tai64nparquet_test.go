@@ -0,0 +1,69 @@
+package tai64nparquet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektra/tai64n"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func writeMoments(t *testing.T, column string, moments []tai64n.TAI64N) []byte {
+	wf, err := buffer.NewBufferFile(nil)
+	require.NoError(t, err)
+
+	err = Write(wf, column, moments)
+	require.NoError(t, err)
+
+	return wf.(buffer.BufferFile).Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	leap := tai64n.AllLeapMoments[len(tai64n.AllLeapMoments)-1].Moment
+
+	moments := []tai64n.TAI64N{
+		*leap.Add(-time.Second),
+		*leap,
+		*leap.Add(500 * time.Millisecond),
+		*leap.Add(time.Second),
+	}
+
+	rf, err := buffer.NewBufferFile(writeMoments(t, "time", moments))
+	require.NoError(t, err)
+
+	got, err := Read(rf, "time")
+	require.NoError(t, err)
+
+	assert.Equal(t, moments, got)
+}
+
+func TestAnnotation(t *testing.T) {
+	rf, err := buffer.NewBufferFile(writeMoments(t, "time", nil))
+	require.NoError(t, err)
+
+	pr, err := reader.NewParquetColumnReader(rf, 1)
+	require.NoError(t, err)
+
+	defer pr.ReadStop()
+
+	kvs := pr.Footer.GetKeyValueMetadata()
+	require.Len(t, kvs, 1)
+
+	assert.Equal(t, MetadataKey, kvs[0].Key)
+	assert.Equal(t, "time", *kvs[0].Value)
+
+	_, err = Read(rf, "other")
+	assert.Equal(t, ErrNotMoments, err)
+}
+
+func TestColumnName(t *testing.T) {
+	wf, err := buffer.NewBufferFile(nil)
+	require.NoError(t, err)
+
+	for _, name := range []string{"", "a.b", "a,b", "a=b", `a"b`} {
+		assert.Equal(t, ErrColumnName, Write(wf, name, nil), name)
+	}
+}
@@ -0,0 +1,91 @@
+// Package tai64nslog stamps log/slog records with TAI64N labels, either
+// in place of the usual time or alongside it.
+package tai64nslog
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/vektra/tai64n"
+)
+
+// The key of the attribute added by Handler.
+const Key = "tai"
+
+// Replace the record time with its TAI64N label. It is suitable for use
+// as the ReplaceAttr of a slog.HandlerOptions.
+func ReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
+		return slog.String(slog.TimeKey, tai64n.FromTime(a.Value.Time()).Label())
+	}
+
+	return a
+}
+
+// Create a slog.TextHandler whose record times are TAI64N labels. Any
+// ReplaceAttr in opts is called after the time has been replaced.
+func NewTextHandler(w io.Writer, opts *slog.HandlerOptions) *slog.TextHandler {
+	return slog.NewTextHandler(w, withReplaceAttr(opts))
+}
+
+// Create a slog.JSONHandler whose record times are TAI64N labels. Any
+// ReplaceAttr in opts is called after the time has been replaced.
+func NewJSONHandler(w io.Writer, opts *slog.HandlerOptions) *slog.JSONHandler {
+	return slog.NewJSONHandler(w, withReplaceAttr(opts))
+}
+
+func withReplaceAttr(opts *slog.HandlerOptions) *slog.HandlerOptions {
+	var o slog.HandlerOptions
+
+	if opts != nil {
+		o = *opts
+	}
+
+	next := o.ReplaceAttr
+
+	o.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+		a = ReplaceAttr(groups, a)
+
+		if next != nil {
+			a = next(groups, a)
+		}
+
+		return a
+	}
+
+	return &o
+}
+
+// Handler wraps another slog.Handler, adding the TAI64N label of each
+// record's time as an attribute named Key. The attribute is added to the
+// record, so it is qualified by any groups the handler was opened with.
+type Handler struct {
+	handler slog.Handler
+}
+
+// Wrap h so that every record carries a TAI64N label.
+func NewHandler(h slog.Handler) *Handler {
+	return &Handler{h}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if !r.Time.IsZero() {
+		r = r.Clone()
+		r.AddAttrs(slog.String(Key, tai64n.FromTime(r.Time).Label()))
+	}
+
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{h.handler.WithAttrs(attrs)}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{h.handler.WithGroup(name)}
+}
@@ -0,0 +1,73 @@
+package tai64nslog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektra/tai64n"
+)
+
+var moment = time.Date(2014, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+func record() slog.Record {
+	return slog.NewRecord(moment, slog.LevelInfo, "hello", 0)
+}
+
+func TestReplaceAttr(t *testing.T) {
+	a := ReplaceAttr(nil, slog.Time(slog.TimeKey, moment))
+	assert.Equal(t, "@4000000053618EA300000000", a.Value.String())
+
+	a = ReplaceAttr([]string{"g"}, slog.Time(slog.TimeKey, moment))
+	assert.Equal(t, slog.KindTime, a.Value.Kind())
+}
+
+func TestJSONHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	h := NewJSONHandler(&buf, nil)
+	require.NoError(t, h.Handle(context.Background(), record()))
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+
+	assert.Equal(t, "@4000000053618EA300000000", out[slog.TimeKey])
+	assert.Equal(t, "hello", out[slog.MessageKey])
+}
+
+func TestTextHandlerChainsReplaceAttr(t *testing.T) {
+	var buf bytes.Buffer
+
+	var seen string
+
+	h := NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				seen = a.Value.String()
+			}
+			return a
+		},
+	})
+	require.NoError(t, h.Handle(context.Background(), record()))
+
+	assert.Equal(t, "@4000000053618EA300000000", seen)
+	assert.Contains(t, buf.String(), "time=@4000000053618EA300000000")
+}
+
+func TestHandlerAddsAttr(t *testing.T) {
+	var buf bytes.Buffer
+
+	h := NewHandler(slog.NewJSONHandler(&buf, nil))
+	require.NoError(t, h.Handle(context.Background(), record()))
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+
+	assert.Equal(t, tai64n.FromTime(moment).Label(), out[Key])
+	assert.NotNil(t, out[slog.TimeKey])
+}
@@ -0,0 +1,206 @@
+package tai64n
+
+import (
+	"sync"
+	"time"
+)
+
+// A Ticker delivers the moment of each TAI boundary of it's period, such
+// as each second or each 10 seconds, on C. Unlike time.Ticker the ticks
+// are aligned to the boundaries rather than to when it was started, and
+// a leap second is a boundary like any other.
+//
+// As with time.Ticker, ticks are dropped for slow receivers.
+type Ticker struct {
+	C <-chan *TAI64N
+
+	c     chan *TAI64N
+	clock Clock
+	stop  chan struct{}
+	once  sync.Once
+}
+
+// Create a Ticker firing at every multiple of every since the TAI64
+// epoch, as read from clock. every must be a positive whole number of
+// seconds.
+//
+// SystemClock repeats 23:59:59 during a leap second, as the UTC system
+// time does, so a Ticker on it delivers the leap second late. Use a
+// Monotonic, which counts TAI seconds, for ticks on time across one.
+func NewTicker(clock Clock, every time.Duration) *Ticker {
+	if every <= 0 || every%time.Second != 0 {
+		panic("tai64n: NewTicker period must be a positive number of seconds")
+	}
+
+	c := make(chan *TAI64N, 1)
+
+	t := &Ticker{
+		C:     c,
+		c:     c,
+		clock: clock,
+		stop:  make(chan struct{}),
+	}
+
+	period := uint64(every / time.Second)
+
+	go t.run(nextBoundary(clock.Now(), period), period)
+
+	return t
+}
+
+// Turn off the ticker. No more ticks will be sent.
+func (t *Ticker) Stop() {
+	t.once.Do(func() { close(t.stop) })
+}
+
+func (t *Ticker) run(next *TAI64N, period uint64) {
+	step := time.Duration(period) * time.Second
+
+	for {
+		if !sleepUntil(t.clock, next, t.stop) {
+			return
+		}
+
+		select {
+		case t.c <- next:
+		default:
+		}
+
+		next = next.Add(step)
+
+		// If the boundary after next has passed too, such as after the
+		// system was suspended, skip to the coming one rather than
+		// sending each missed tick.
+		if now := t.clock.Now(); !now.Before(next.Add(step)) {
+			next = nextBoundary(now, period)
+		}
+	}
+}
+
+// Return the first multiple of period seconds after the moment
+func nextBoundary(t *TAI64N, period uint64) *TAI64N {
+	secs := t.Seconds - TAI64OriginalBase
+
+	return &TAI64N{Seconds: TAI64OriginalBase + secs - secs%period + period}
+}
+
+// Wait until clock reaches t, returning false if stop is closed first.
+// Clocks that aren't AlarmClocks are waited on in real time, which like
+// TAI counts leap seconds, and rechecked once the wait is over in case
+// they were stepped meanwhile.
+func sleepUntil(clock Clock, t *TAI64N, stop <-chan struct{}) bool {
+	if ac, ok := clock.(AlarmClock); ok {
+		select {
+		case <-ac.Alarm(t):
+			return true
+		case <-stop:
+			return false
+		}
+	}
+
+	for {
+		wait := t.taiSince(clock.Now())
+		if wait <= 0 {
+			return true
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+		case <-stop:
+			timer.Stop()
+			return false
+		}
+	}
+}
+
+// A Timer sends the clock's moment on C once it reaches the given
+// deadline, waiting for however many TAI seconds remain, including any
+// leap second in between.
+type Timer struct {
+	C <-chan *TAI64N
+
+	c     chan *TAI64N
+	clock Clock
+
+	mu   sync.Mutex
+	stop chan struct{} // closed to cancel the wait, nil once fired or stopped
+}
+
+// Create a Timer that fires when clock reaches deadline, or immediately
+// if it has already. As with NewTicker, use a Monotonic for a deadline
+// within a leap second to be met on time.
+func NewTimer(clock Clock, deadline *TAI64N) *Timer {
+	c := make(chan *TAI64N, 1)
+
+	t := &Timer{C: c, c: c, clock: clock}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.arm(deadline)
+
+	return t
+}
+
+// Prevent the timer from firing, returning false if it had already fired
+// or been stopped.
+func (t *Timer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.disarm()
+}
+
+// Change the timer to fire at deadline, returning true if it had been
+// active.
+func (t *Timer) Reset(deadline *TAI64N) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	active := t.disarm()
+
+	t.arm(deadline)
+
+	return active
+}
+
+func (t *Timer) arm(deadline *TAI64N) {
+	stop := make(chan struct{})
+	t.stop = stop
+
+	go t.wait(*deadline, stop)
+}
+
+func (t *Timer) disarm() bool {
+	if t.stop == nil {
+		return false
+	}
+
+	close(t.stop)
+	t.stop = nil
+
+	return true
+}
+
+func (t *Timer) wait(deadline TAI64N, stop chan struct{}) {
+	if !sleepUntil(t.clock, &deadline, stop) {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Stopped or reset after the deadline was reached.
+	if t.stop != stop {
+		return
+	}
+
+	t.stop = nil
+
+	select {
+	case t.c <- t.clock.Now():
+	default:
+	}
+}
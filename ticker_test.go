@@ -0,0 +1,183 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextBoundary(t *testing.T) {
+	m := FromTime(time.Date(2014, time.May, 1, 0, 0, 0, 5, time.UTC))
+
+	n := nextBoundary(m, 1)
+	assert.Equal(t, m.Seconds+1, n.Seconds)
+	assert.Equal(t, uint32(0), n.Nanoseconds)
+
+	n = nextBoundary(m, 10)
+	assert.Equal(t, uint64(0), (n.Seconds-TAI64OriginalBase)%10)
+	assert.True(t, n.After(m))
+}
+
+func TestNextBoundaryAtLeap(t *testing.T) {
+	leap := FromTime(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)).Add(-time.Second)
+
+	n := nextBoundary(leap.Add(-500*time.Millisecond), 1)
+	assert.True(t, n.Equal(leap))
+
+	_, _, sec := n.Clock()
+	assert.Equal(t, 60, sec)
+}
+
+func TestTicker(t *testing.T) {
+	tk := NewTicker(NewMonotonic(), time.Second)
+	defer tk.Stop()
+
+	select {
+	case tick := <-tk.C:
+		assert.Equal(t, uint32(0), tick.Nanoseconds)
+		assert.False(t, Now().Before(tick))
+	case <-time.After(3 * time.Second):
+		t.Fatal("ticker did not fire")
+	}
+}
+
+func TestTimer(t *testing.T) {
+	deadline := Now().Add(20 * time.Millisecond)
+	tm := NewTimer(NewMonotonic(), deadline)
+
+	select {
+	case fired := <-tm.C:
+		assert.False(t, fired.Before(deadline))
+	case <-time.After(2 * time.Second):
+		t.Fatal("timer did not fire")
+	}
+
+	assert.False(t, tm.Stop())
+}
+
+func TestTimerStopAndReset(t *testing.T) {
+	tm := NewTimer(NewMonotonic(), Now().Add(time.Hour))
+
+	require.True(t, tm.Stop())
+
+	assert.False(t, tm.Reset(Now().Add(-time.Second)))
+
+	select {
+	case <-tm.C:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timer did not fire")
+	}
+}
+
+// The leap second at the end of 2016 and a FakeClock just before it
+func clockBeforeLeap() (*TAI64N, *FakeClock) {
+	leap := AllLeapMoments[len(AllLeapMoments)-1].Moment
+
+	return leap, NewFakeClock(leap.Add(-1500 * time.Millisecond))
+}
+
+// Wait until something is waiting on an alarm of the clock
+func waitForAlarm(t *testing.T, clock *FakeClock) {
+	require.Eventually(t, func() bool {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+		return len(clock.alarms) > 0
+	}, 3*time.Second, time.Millisecond)
+}
+
+func receive(t *testing.T, c <-chan *TAI64N) *TAI64N {
+	select {
+	case m := <-c:
+		return m
+	case <-time.After(3 * time.Second):
+		t.Fatal("nothing received")
+		return nil
+	}
+}
+
+func TestTickerAcrossLeap(t *testing.T) {
+	leap, clock := clockBeforeLeap()
+
+	tk := NewTicker(clock, time.Second)
+	defer tk.Stop()
+
+	for _, want := range []*TAI64N{leap.Add(-time.Second), leap, leap.Add(time.Second)} {
+		waitForAlarm(t, clock)
+		clock.Advance(time.Second)
+
+		tick := receive(t, tk.C)
+		assert.True(t, tick.Equal(want), "%s", tick)
+	}
+
+	// The ticks are 23:59:59, 23:59:60 and then 00:00:00.
+	_, _, sec := leap.Clock()
+	assert.Equal(t, 60, sec)
+}
+
+func TestTickerSkipsMissedTicks(t *testing.T) {
+	leap, clock := clockBeforeLeap()
+
+	tk := NewTicker(clock, time.Second)
+	defer tk.Stop()
+
+	waitForAlarm(t, clock)
+	clock.Advance(time.Second)
+	receive(t, tk.C)
+
+	waitForAlarm(t, clock)
+	clock.Advance(10 * time.Second)
+
+	tick := receive(t, tk.C)
+	assert.True(t, tick.Equal(leap), "%s", tick)
+
+	// The ticks in between were missed, so the next is the coming one.
+	waitForAlarm(t, clock)
+	clock.Advance(time.Second)
+
+	tick = receive(t, tk.C)
+	assert.True(t, tick.Equal(leap.Add(10*time.Second)), "%s", tick)
+}
+
+func TestTimerAtLeap(t *testing.T) {
+	leap, clock := clockBeforeLeap()
+
+	tm := NewTimer(clock, leap)
+
+	clock.Advance(time.Second)
+
+	select {
+	case <-tm.C:
+		t.Fatal("timer fired early")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(500 * time.Millisecond)
+
+	fired := receive(t, tm.C)
+	assert.True(t, fired.Equal(leap), "%s", fired)
+
+	assert.False(t, tm.Stop())
+}
+
+func TestTimerResetOnFakeClock(t *testing.T) {
+	leap, clock := clockBeforeLeap()
+
+	tm := NewTimer(clock, leap)
+
+	require.True(t, tm.Reset(leap.Add(time.Second)))
+
+	clock.Advance(1500 * time.Millisecond)
+
+	select {
+	case <-tm.C:
+		t.Fatal("timer fired at it's old deadline")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+
+	fired := receive(t, tm.C)
+	assert.True(t, fired.Equal(leap.Add(time.Second)), "%s", fired)
+}
@@ -0,0 +1,81 @@
+package tai64n
+
+import "time"
+
+// How the moments of a Ticks are spaced.
+type Cadence int
+
+const (
+	// Ticks are a fixed number of TAI seconds apart.
+	TAICadence Cadence = iota
+
+	// Ticks fall on UTC boundaries, such as the start of each minute or
+	// each day, so the span containing a leap second is a second longer.
+	UTCCadence
+)
+
+// Iterates over the range from a start moment up to an end moment in
+// steps, yielding each step as an Interval.
+//
+// With TAICadence the steps start at the start moment and are all the
+// same length. With UTCCadence they start at each multiple of the step in
+// UTC, as for time.Truncate, which for steps that divide a day are the
+// civil boundaries; the first step runs from the start moment to the
+// first boundary after it.
+type Ticks struct {
+	end     TAI64N
+	step    time.Duration
+	cadence Cadence
+
+	next TAI64N
+	cur  Interval
+}
+
+// Create a Ticks over start up to end. step must be positive.
+func NewTicks(start, end *TAI64N, step time.Duration, cadence Cadence) *Ticks {
+	if step <= 0 {
+		panic("tai64n: non-positive step for NewTicks")
+	}
+
+	return &Ticks{
+		end:     *end,
+		step:    step,
+		cadence: cadence,
+		next:    *start,
+	}
+}
+
+// Advance to the next step, returning false once the end is reached.
+func (t *Ticks) Next() bool {
+	if !t.next.Before(&t.end) {
+		return false
+	}
+
+	var next *TAI64N
+
+	switch t.cadence {
+	case UTCCadence:
+		next = FromTime(t.next.Time().Truncate(t.step).Add(t.step))
+	default:
+		next = t.next.Add(t.step)
+	}
+
+	next = minMoment(next, &t.end)
+
+	t.cur = Interval{t.next, *next}
+	t.next = *next
+
+	return true
+}
+
+// Return the moment the current step starts at
+func (t *Ticks) Moment() *TAI64N {
+	m := t.cur.Start
+	return &m
+}
+
+// Return the span of the current step, which is cut short by the end of
+// the range.
+func (t *Ticks) Interval() Interval {
+	return t.cur
+}
@@ -0,0 +1,65 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicksTAI(t *testing.T) {
+	start := FromTime(time.Date(2012, time.June, 30, 23, 58, 30, 0, time.UTC))
+	end := start.Add(3 * time.Minute)
+
+	ticks := NewTicks(start, end, time.Minute, TAICadence)
+
+	var got []Interval
+
+	for ticks.Next() {
+		got = append(got, ticks.Interval())
+	}
+
+	assert.Equal(t, 3, len(got))
+
+	for _, iv := range got {
+		assert.Equal(t, time.Minute, iv.Duration())
+	}
+
+	assert.True(t, got[0].Start.Equal(start))
+}
+
+func TestTicksUTCAcrossLeap(t *testing.T) {
+	start := FromTime(time.Date(2012, time.June, 30, 23, 58, 30, 0, time.UTC))
+	end := FromTime(time.Date(2012, time.July, 1, 0, 1, 0, 0, time.UTC))
+
+	ticks := NewTicks(start, end, time.Minute, UTCCadence)
+
+	var got []Interval
+
+	for ticks.Next() {
+		got = append(got, ticks.Interval())
+	}
+
+	assert.Equal(t, 3, len(got))
+
+	assert.Equal(t, 30*time.Second, got[0].Duration())
+	assert.Equal(t, 61*time.Second, got[1].Duration())
+	assert.Equal(t, time.Minute, got[2].Duration())
+
+	assert.True(t, got[2].Start.Equal(FromTime(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC))))
+}
+
+func TestTicksUTCFromLeapSecond(t *testing.T) {
+	leap := FromTime(time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)).Add(-time.Second)
+
+	ticks := NewTicks(leap, leap.Add(time.Hour), 24*time.Hour, UTCCadence)
+
+	assert.True(t, ticks.Next())
+	assert.True(t, ticks.Moment().Equal(leap))
+	assert.Equal(t, time.Second, ticks.Interval().Duration())
+
+	assert.True(t, ticks.Next())
+	assert.Equal(t, time.Hour-time.Second, ticks.Interval().Duration())
+
+	assert.False(t, ticks.Next())
+}
@@ -0,0 +1,50 @@
+package tai64n
+
+import "errors"
+
+// Returned when a YAML value can't be read as a moment
+var ErrInvalidYAML = errors.New("tai64n: invalid YAML time")
+
+// Encode as a RFC3339 string, with a leap second as 23:59:60. As with
+// MarshalJSON, years outside of [0,9999] are an error.
+func (tai TAI64N) MarshalYAML() (interface{}, error) {
+	return tai.rfc3339()
+}
+
+// Decode a label, a RFC3339 time, or a mapping of the seconds and
+// nanoseconds fields. The signature is the one both yaml.v2 and yaml.v3
+// accept.
+func (tai *TAI64N) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+
+	if err := unmarshal(&s); err == nil {
+		parsed, err := parseText(s)
+		if err != nil {
+			return err
+		}
+
+		*tai = parsed
+
+		return nil
+	}
+
+	var fields struct {
+		Seconds     *uint64 `yaml:"seconds"`
+		Nanoseconds uint32  `yaml:"nanoseconds"`
+	}
+
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+
+	if fields.Seconds == nil || fields.Nanoseconds >= 1e9 {
+		return ErrInvalidYAML
+	}
+
+	*tai = TAI64N{
+		Seconds:     *fields.Seconds,
+		Nanoseconds: fields.Nanoseconds,
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package tai64n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlDoc struct {
+	Time TAI64N `yaml:"time"`
+}
+
+func TestMarshalYAML(t *testing.T) {
+	moment := testMoment.Add(500 * time.Millisecond)
+
+	data, err := yaml.Marshal(yamlDoc{*moment})
+	require.NoError(t, err)
+
+	assert.Equal(t, "time: \"2014-05-01T00:00:00.5Z\"\n", string(data))
+
+	var doc yamlDoc
+
+	err = yaml.Unmarshal(data, &doc)
+	require.NoError(t, err)
+
+	assert.True(t, doc.Time.Equal(moment))
+}
+
+func TestMarshalYAMLYearRange(t *testing.T) {
+	_, err := yaml.Marshal(yamlDoc{})
+	assert.ErrorIs(t, err, ErrYearRange)
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	moment := testMoment.Add(500 * time.Millisecond)
+
+	for _, data := range []string{
+		"time: \"@4000000053618ea31dcd6500\"",
+		"time: 2014-05-01T00:00:00.5Z",
+		"time: 2014-05-01T02:00:00.5+02:00",
+		"time: {seconds: 4611686019826290339, nanoseconds: 500000000}",
+	} {
+		var doc yamlDoc
+
+		err := yaml.Unmarshal([]byte(data), &doc)
+		require.NoError(t, err, data)
+
+		assert.True(t, doc.Time.Equal(moment), data)
+	}
+
+	var doc yamlDoc
+
+	err := yaml.Unmarshal([]byte("time: 2016-12-31T23:59:60Z"), &doc)
+	require.NoError(t, err)
+
+	assert.True(t, doc.Time.Equal(AllLeapMoments[len(AllLeapMoments)-1].Moment))
+
+	for _, data := range []string{
+		"time: \"@40\"",
+		"time: yesterday",
+		"time: 2014-05-01T23:59:60Z",
+		"time: {nanoseconds: 5}",
+		"time: {seconds: 1, nanoseconds: 1000000000}",
+		"time: [1, 2]",
+	} {
+		var doc yamlDoc
+
+		assert.Error(t, yaml.Unmarshal([]byte(data), &doc), data)
+	}
+}